@@ -3,17 +3,9 @@ package main
 import (
 	"os"
 
-	"github.com/es-debug/backend-academy-2024-go-template/internal/application/game"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/cli"
 )
 
 func main() {
-	g, err := game.New()
-	if err != nil {
-		os.Exit(1)
-	}
-
-	err = g.Run()
-	if err != nil {
-		os.Exit(1)
-	}
+	os.Exit(cli.Run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
 }
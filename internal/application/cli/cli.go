@@ -0,0 +1,147 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/game"
+)
+
+// Коды завершения программы.
+const (
+	ExitOK    = 0
+	ExitError = 1
+	ExitUsage = 2
+)
+
+// defaultCommand - подкоманда, выполняемая при запуске без аргументов.
+const defaultCommand = "play"
+
+// Version - версия программы, задаваемая при сборке через -ldflags "-X ...cli.Version=...".
+var Version = "dev"
+
+// errUsage - ошибка некорректного использования командной строки.
+var errUsage = errors.New("usage error")
+
+// command хранит описание подкоманды и функцию её выполнения.
+type command struct {
+	description string
+	run         func(args []string, stdin io.Reader, stdout, stderr io.Writer) error
+}
+
+// commands - словарь, сопоставляющий именам подкоманд их реализацию.
+var commands = map[string]command{
+	"play":            {description: "сыграть партию", run: runPlay},
+	"list-categories": {description: "вывести список категорий словаря", run: runListCategories},
+	"list-themes":     {description: "вывести список тем оформления", run: runListThemes},
+	"replay":          {description: "replay <файл>: воспроизвести запись игры", run: runReplay},
+	"validate":        {description: "проверить файлы конфига, словаря и кадров", run: runValidate},
+	"stats":           {description: "stats [reset-seen]: вывести статистику сыгранных игр или забыть встречавшиеся слова", run: runStats},
+	"config":          {description: "config show: вывести итоговый конфиг и источники значений", run: runConfig},
+	"convert":         {description: "convert <из> <в>: перекодировать файл данных между JSON, YAML и TOML", run: runConvert},
+	"words":           {description: "words [команда]: редактировать словарь", run: runWords},
+	"dict":            {description: "dict <команда>: искать слова в списке слов языка по префиксу и шаблону", run: runDict},
+	"version":         {description: "вывести версию программы", run: runVersion},
+}
+
+// Run разбирает аргументы командной строки, выполняет подкоманду и возвращает код завершения.
+// Игра и редактор словаря читают ввод из stdin, вывод команд пишется в stdout.
+// Ошибки выводятся в stderr вместе с цепочкой обёрнутых причин, кроме уже выведенных игровой консолью.
+func Run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		args = []string{defaultCommand}
+	}
+
+	name := args[0]
+	if name == "help" || name == "-h" || name == "--help" {
+		printUsage(stdout)
+		return ExitOK
+	}
+
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(stderr, "hangman: unknown command %q\n\n", name)
+		printUsage(stderr)
+
+		return ExitUsage
+	}
+
+	err := cmd.run(args[1:], stdin, stdout, stderr)
+
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+		return ExitOK
+	case errors.Is(err, game.ErrDisplayed):
+		return ExitError
+	case errors.Is(err, errUsage):
+		fmt.Fprintf(stderr, "hangman %s: %v\n", name, err)
+		return ExitUsage
+	default:
+		fmt.Fprintf(stderr, "hangman %s: %v\n", name, err)
+		return ExitError
+	}
+}
+
+// printUsage выводит список подкоманд.
+func printUsage(w io.Writer) {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	fmt.Fprintln(w, "Использование: hangman <команда> [флаги]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Команды:")
+
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-16s %s\n", name, commands[name].description)
+	}
+}
+
+// newFlagSet возвращает набор флагов подкоманды, выводящий справку в stderr.
+func newFlagSet(name string, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Использование: hangman %s [флаги]\n", name)
+		fs.SetOutput(stderr)
+		fs.PrintDefaults()
+		fs.SetOutput(io.Discard)
+	}
+
+	return fs
+}
+
+// parseFlags разбирает флаги подкоманды и проверяет отсутствие лишних аргументов.
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if fs.NArg() != 0 {
+		return fmt.Errorf("%w: unexpected arguments %q", errUsage, fs.Args())
+	}
+
+	return nil
+}
+
+// parseArgs разбирает флаги подкоманды, оставляя аргументы после них в fs.Args().
+// Ошибка разбора оборачивается в errUsage, кроме запроса справки.
+func parseArgs(fs *flag.FlagSet, args []string) error {
+	err := fs.Parse(args)
+	if errors.Is(err, flag.ErrHelp) {
+		return err
+	}
+
+	if err != nil {
+		return fmt.Errorf("%w: %w", errUsage, err)
+	}
+
+	return nil
+}
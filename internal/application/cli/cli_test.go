@@ -0,0 +1,220 @@
+package cli_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/cli"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/game"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/words"
+	"github.com/stretchr/testify/assert"
+)
+
+const filesDir = "../../infrastructure/files"
+
+// dataArgs - флаги путей к файлам игровых данных проекта относительно каталога пакета.
+var dataArgs = []string{
+	"--config", filesDir + "/config.json",
+	"--words", filesDir + "/words.json",
+	"--frames", filesDir + "/frames.json",
+	"--themes", filesDir + "/themes",
+}
+
+// run выполняет команду с данными проекта и вводом in в отдельном каталоге данных пользователя
+// и возвращает код завершения, вывод и вывод ошибок.
+func run(t *testing.T, in string, args ...string) (code int, stdout, stderr string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	var out, errOut bytes.Buffer
+
+	code = cli.Run(args, strings.NewReader(in), &out, &errOut)
+
+	return code, out.String(), errOut.String()
+}
+
+// withData добавляет к аргументам команды флаги путей к файлам игровых данных.
+func withData(command string, args ...string) []string {
+	return append(append([]string{command}, dataArgs...), args...)
+}
+
+func TestRunUsage(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		code     int
+		stdout   string
+		stderr   string
+		noStdout bool
+	}{
+		{name: "help", args: []string{"help"}, code: cli.ExitOK, stdout: "Использование: hangman"},
+		{name: "short help flag", args: []string{"-h"}, code: cli.ExitOK, stdout: "list-categories"},
+		{name: "long help flag", args: []string{"--help"}, code: cli.ExitOK, stdout: "version"},
+		{name: "unknown command", args: []string{"fly"}, code: cli.ExitUsage, stderr: `hangman: unknown command "fly"`, noStdout: true},
+		{name: "unknown command usage", args: []string{"fly"}, code: cli.ExitUsage, stderr: "Использование: hangman"},
+		{name: "unknown flag", args: []string{"play", "--nope"}, code: cli.ExitUsage, stderr: "-nope"},
+		{name: "bad flag value", args: []string{"play", "--seed", "x"}, code: cli.ExitUsage, stderr: "-seed"},
+		{name: "extra argument", args: []string{"version", "now"}, code: cli.ExitUsage, stderr: "hangman version:"},
+		{name: "command help", args: []string{"play", "-h"}, code: cli.ExitOK, stderr: "-daily"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, stdout, stderr := run(t, "", tt.args...)
+
+			assert.Equal(t, tt.code, code)
+			assert.Contains(t, stdout, tt.stdout)
+			assert.Contains(t, stderr, tt.stderr)
+
+			if tt.noStdout {
+				assert.Empty(t, stdout)
+			}
+		})
+	}
+}
+
+func TestRunPlayIncompatibleFlags(t *testing.T) {
+	tests := []struct {
+		name   string
+		args   []string
+		stderr string
+	}{
+		{name: "daily and seed", args: []string{"--daily", "--seed", "1"}, stderr: "flags -daily and -seed are mutually exclusive"},
+		{name: "daily and practice", args: []string{"--daily", "--practice"}, stderr: "flags -daily and -practice are mutually exclusive"},
+		{name: "daily and word", args: []string{"--daily", "--word", "кот"}, stderr: "flags -daily and -word are mutually exclusive"},
+		{name: "daily and tags", args: []string{"--daily", "--tags", "еда"}, stderr: "daily challenge can`t be combined with word filters"},
+		{name: "daily and categories", args: []string{"--daily", "--category", "пища,персонажи"}, stderr: "can`t be combined with word filters"},
+		{name: "daily and length", args: []string{"--daily", "--length", "5-8"}, stderr: "can`t be combined with word filters"},
+		{name: "daily and unseen", args: []string{"--daily", "--unseen"}, stderr: "can`t be combined with word filters"},
+		{name: "word and category", args: []string{"--word", "кот", "--category", "пища"}, stderr: "custom word can`t be combined"},
+		{name: "word and min letters", args: []string{"--word", "кот", "--min-letters", "2"}, stderr: "custom word can`t be combined"},
+		{name: "word without letters", args: []string{"--word", "123"}, stderr: `invalid word "123"`},
+		{name: "bad length", args: []string{"--length", "8-5"}, stderr: "lower bound is greater than upper bound"},
+		{name: "negative min letters", args: []string{"--min-letters", "-1"}, stderr: "min-letters must not be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, stdout, stderr := run(t, "", withData("play", tt.args...)...)
+
+			assert.Equal(t, cli.ExitUsage, code)
+			assert.Empty(t, stdout)
+			assert.Contains(t, stderr, "hangman play: ")
+			assert.Contains(t, stderr, tt.stderr)
+		})
+	}
+}
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		category string
+		tags     string
+		length   string
+		minDist  int
+		expected game.Options
+		err      error
+	}{
+		{
+			name:     "single category",
+			category: " пища ",
+			expected: game.Options{Category: "пища"},
+		},
+		{
+			name:     "several categories and tags",
+			category: "пища, персонажи,",
+			tags:     "еда,,сказки",
+			expected: game.Options{Filter: words.Query{Categories: []string{"пища", "персонажи"}, Tags: []string{"еда", "сказки"}}},
+		},
+		{
+			name:     "length range",
+			length:   "5-8",
+			expected: game.Options{Filter: words.Query{MinLength: 5, MaxLength: 8}},
+		},
+		{
+			name:     "lower bound",
+			length:   "5-",
+			minDist:  3,
+			expected: game.Options{Filter: words.Query{MinLength: 5, MinDistinct: 3}},
+		},
+		{
+			name:   "bad length",
+			length: "пять",
+			err:    words.ErrInvalidRange,
+		},
+		{
+			name:    "negative min letters",
+			minDist: -1,
+			err:     cli.ErrUsage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := game.Options{Filter: words.Query{MinDistinct: tt.minDist}}
+
+			err := cli.ParseFilter(&opts, tt.category, tt.tags, tt.length)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				assert.ErrorIs(t, err, cli.ErrUsage)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, opts)
+		})
+	}
+}
+
+func TestRunDataCommands(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{name: "validate", args: withData("validate"), expected: "Данные корректны\n"},
+		{name: "list categories", args: withData("list-categories"), expected: "видеоигры\nперсонажи\nпища\n"},
+		{name: "list themes", args: withData("list-themes"), expected: "виселица\nракета\nснеговик\nшарик\n"},
+		{name: "version", args: []string{"version"}, expected: "hangman " + cli.Version + "\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, stdout, stderr := run(t, "", tt.args...)
+
+			assert.Equal(t, cli.ExitOK, code)
+			assert.Equal(t, tt.expected, stdout)
+			assert.Empty(t, stderr)
+		})
+	}
+}
+
+func TestRunValidateBrokenWords(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "words.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{"пища": [`), 0o600))
+
+	code, stdout, stderr := run(t, "", withData("validate", "--words", path)...)
+
+	assert.Equal(t, cli.ExitError, code)
+	assert.Empty(t, stdout)
+	assert.Contains(t, stderr, "hangman validate: can`t create game")
+}
+
+func TestRunPlayCustomWord(t *testing.T) {
+	args := withData("play", "--word", "кот", "--difficulty", "лёгкая", "--theme", "шарик", "--seed", "1",
+		"--frame-delay", "0", "--transition-delay", "0", "--color", "never")
+
+	code, stdout, stderr := run(t, "к\nкот\n", args...)
+
+	assert.Equal(t, cli.ExitOK, code)
+	assert.Contains(t, stdout, "Шарик улетает в небо!")
+	assert.Contains(t, stdout, "Зерно игры: 1\n")
+	assert.Empty(t, stderr)
+}
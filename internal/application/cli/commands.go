@@ -0,0 +1,428 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/editor"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/game"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/random"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/words"
+)
+
+// runPlay запускает партию с параметрами из флагов.
+func runPlay(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	opts := game.DefaultOptions()
+	opts.Input, opts.Output = stdin, stdout
+	fs := newFlagSet("play", stderr)
+	addDataFlags(fs, &opts)
+	category := fs.String("category", "", "категория слова или несколько категорий через запятую (по-умолчанию выбирается в меню)")
+	fs.StringVar(&opts.Difficulty, "difficulty", "", "уровень сложности (по-умолчанию выбирается в меню)")
+	fs.StringVar(&opts.Theme, "theme", "", "тема оформления (по-умолчанию выбирается в меню)")
+	addSettingFlags(fs, &opts)
+	seed := fs.Uint64("seed", 0, "зерно генератора случайных чисел для воспроизведения игры")
+	isDaily := fs.Bool("daily", false, "сыграть в ежедневное испытание")
+	fs.BoolVar(&opts.Practice, "practice", false, "тренировочная игра: ходы можно отменять, результат не учитывается в статистике")
+	tags := fs.String("tags", "", "теги слова через запятую: слово должно иметь хотя бы один из них")
+	length := fs.String("length", "", "длина слова: 6, диапазон 5-8 или границы 5- и -8")
+	fs.IntVar(&opts.Filter.MinDistinct, "min-letters", 0, "наименьшее количество разных букв в слове")
+	fs.BoolVar(&opts.Unseen, "unseen", false, "исключить уже встречавшиеся слова")
+	fs.StringVar(&opts.Word, "word", "", "загадать своё слово вместо слова из словаря, например для игры вдвоём")
+
+	err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if *isDaily && opts.Practice {
+		return fmt.Errorf("%w: flags -daily and -practice are mutually exclusive", errUsage)
+	}
+
+	if *isDaily && isFlagSet(fs, "seed") {
+		return fmt.Errorf("%w: flags -daily and -seed are mutually exclusive", errUsage)
+	}
+
+	err = parseFilter(&opts, *category, *tags, *length)
+	if err != nil {
+		return err
+	}
+
+	if *isDaily && (opts.Filter.HasFilters() || len(opts.Filter.Categories) != 0 || opts.Unseen) {
+		return fmt.Errorf("%w: daily challenge can`t be combined with word filters", errUsage)
+	}
+
+	err = checkCustomWord(fs, opts, *isDaily)
+	if err != nil {
+		return err
+	}
+
+	if isFlagSet(fs, "seed") {
+		opts.Random = random.New(*seed)
+	}
+
+	g, err := game.New(opts)
+	if err != nil {
+		return fmt.Errorf("can`t create game: %w", err)
+	}
+
+	ctx, stop := game.WithSignals(context.Background())
+	defer stop()
+
+	if *isDaily {
+		// Испытание дня выбирается по дате в UTC, а не в местном часовом поясе.
+		err = g.RunDaily(ctx, time.Now().UTC())
+	} else {
+		err = g.Run(ctx)
+	}
+
+	if err != nil {
+		return fmt.Errorf("can`t run game: %w", err)
+	}
+
+	return nil
+}
+
+// parseFilter разбирает флаги категорий, тегов и длины слова в параметры запуска.
+// Единственная категория задаётся как заранее выбранная, несколько категорий - как фильтр слов.
+func parseFilter(opts *game.Options, category, tags, length string) error {
+	categories := splitList(category)
+	if len(categories) == 1 {
+		opts.Category = categories[0]
+	} else {
+		opts.Filter.Categories = categories
+	}
+
+	opts.Filter.Tags = splitList(tags)
+
+	if length != "" {
+		var err error
+
+		opts.Filter.MinLength, opts.Filter.MaxLength, err = words.ParseLengthRange(length)
+		if err != nil {
+			return fmt.Errorf("%w: %w", errUsage, err)
+		}
+	}
+
+	if opts.Filter.MinDistinct < 0 {
+		return fmt.Errorf("%w: min-letters must not be negative, got %d", errUsage, opts.Filter.MinDistinct)
+	}
+
+	return nil
+}
+
+// checkCustomWord проверяет слово, заданное флагом -word: в нём должна быть хотя бы одна буква,
+// и оно не совмещается с ежедневным испытанием и выбором слова из словаря.
+func checkCustomWord(fs *flag.FlagSet, opts game.Options, isDaily bool) error {
+	if !isFlagSet(fs, "word") {
+		return nil
+	}
+
+	if isDaily {
+		return fmt.Errorf("%w: flags -daily and -word are mutually exclusive", errUsage)
+	}
+
+	if opts.Category != "" || opts.Filter.HasFilters() || len(opts.Filter.Categories) != 0 || opts.Unseen {
+		return fmt.Errorf("%w: custom word can`t be combined with category and word filters", errUsage)
+	}
+
+	err := words.CheckWord(opts.Word)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errUsage, err)
+	}
+
+	return nil
+}
+
+// splitList разбивает список через запятую, отбрасывая пробелы по краям и пустые элементы.
+func splitList(list string) []string {
+	var items []string
+
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// runReplay воспроизводит запись игры из файла.
+func runReplay(args []string, _ io.Reader, stdout, stderr io.Writer) error {
+	opts := game.DefaultOptions()
+	opts.Output = stdout
+	fs := newFlagSet("replay", stderr)
+	addDataFlags(fs, &opts)
+	addSettingFlags(fs, &opts)
+	speed := fs.Float64("speed", 1, "во сколько раз ускорить воспроизведение")
+
+	err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if fs.NArg() != 1 {
+		return fmt.Errorf("%w: expected replay file", errUsage)
+	}
+
+	if *speed <= 0 {
+		return fmt.Errorf("%w: speed must be positive, got %v", errUsage, *speed)
+	}
+
+	g, err := game.New(opts)
+	if err != nil {
+		return fmt.Errorf("can`t create game: %w", err)
+	}
+
+	ctx, stop := game.WithSignals(context.Background())
+	defer stop()
+
+	err = g.Replay(ctx, fs.Arg(0), *speed)
+	if err != nil {
+		return fmt.Errorf("can`t replay game: %w", err)
+	}
+
+	return nil
+}
+
+// runListCategories выводит категории словаря.
+func runListCategories(args []string, _ io.Reader, stdout, stderr io.Writer) error {
+	opts := game.DefaultOptions()
+	fs := newFlagSet("list-categories", stderr)
+	addDataFlags(fs, &opts)
+
+	err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	g, err := game.New(opts)
+	if err != nil {
+		return fmt.Errorf("can`t create game: %w", err)
+	}
+
+	for _, category := range g.Categories() {
+		fmt.Fprintln(stdout, category)
+	}
+
+	return nil
+}
+
+// runListThemes выводит доступные темы оформления.
+func runListThemes(args []string, _ io.Reader, stdout, stderr io.Writer) error {
+	opts := game.DefaultOptions()
+	fs := newFlagSet("list-themes", stderr)
+	addDataFlags(fs, &opts)
+
+	err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	g, err := game.New(opts)
+	if err != nil {
+		return fmt.Errorf("can`t create game: %w", err)
+	}
+
+	for _, name := range g.Themes() {
+		fmt.Fprintln(stdout, name)
+	}
+
+	return nil
+}
+
+// runValidate проверяет файлы игровых данных.
+func runValidate(args []string, _ io.Reader, stdout, stderr io.Writer) error {
+	opts := game.DefaultOptions()
+	fs := newFlagSet("validate", stderr)
+	addDataFlags(fs, &opts)
+
+	err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	_, err = game.New(opts)
+	if err != nil {
+		return fmt.Errorf("can`t create game: %w", err)
+	}
+
+	fmt.Fprintln(stdout, "Данные корректны")
+
+	return nil
+}
+
+// runStats выводит статистику сыгранных игр или, с подкомандой reset-seen, забывает встречавшиеся слова.
+func runStats(args []string, _ io.Reader, stdout, stderr io.Writer) error {
+	if len(args) != 0 && args[0] == "reset-seen" {
+		err := parseFlags(newFlagSet("stats reset-seen", stderr), args[1:])
+		if err != nil {
+			return err
+		}
+
+		err = game.ResetSeen()
+		if err != nil {
+			return fmt.Errorf("can`t reset seen words: %w", err)
+		}
+
+		fmt.Fprintln(stdout, "Встречавшиеся слова забыты")
+
+		return nil
+	}
+
+	err := parseFlags(newFlagSet("stats", stderr), args)
+	if err != nil {
+		return err
+	}
+
+	st, err := game.LoadStats()
+	if err != nil {
+		return fmt.Errorf("can`t load stats: %w", err)
+	}
+
+	fmt.Fprintf(stdout, "Сыграно: %d, выиграно: %d (%.0f%%)\n", st.Played, st.Won, st.WinRate())
+	fmt.Fprintf(stdout, "Текущая серия побед: %d, лучшая: %d\n", st.CurrentStreak, st.BestStreak)
+
+	for _, difficulty := range sortedKeys(st.Difficulties) {
+		counter := st.Difficulties[difficulty]
+		fmt.Fprintf(stdout, "  %s: %d/%d (%.0f%%)\n", difficulty, counter.Won, counter.Played, counter.WinRate())
+	}
+
+	return nil
+}
+
+// runConvert перекодирует файл данных в другой формат.
+func runConvert(args []string, _ io.Reader, _, stderr io.Writer) error {
+	fs := newFlagSet("convert", stderr)
+
+	err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if fs.NArg() != 2 {
+		return fmt.Errorf("%w: expected source and destination files", errUsage)
+	}
+
+	err = game.ConvertFile(fs.Arg(0), fs.Arg(1))
+	if err != nil {
+		return fmt.Errorf("can`t convert file: %w", err)
+	}
+
+	return nil
+}
+
+// runConfig выполняет подкоманду работы с конфигом.
+func runConfig(args []string, _ io.Reader, stdout, stderr io.Writer) error {
+	if len(args) == 0 || args[0] != "show" {
+		return fmt.Errorf("%w: expected subcommand \"show\"", errUsage)
+	}
+
+	opts := game.DefaultOptions()
+	fs := newFlagSet("config show", stderr)
+	addDataFlags(fs, &opts)
+	addSettingFlags(fs, &opts)
+
+	err := parseFlags(fs, args[1:])
+	if err != nil {
+		return err
+	}
+
+	layered, err := game.LoadConfig(opts)
+	if err != nil {
+		return fmt.Errorf("can`t load config: %w", err)
+	}
+
+	for _, key := range config.Keys() {
+		value, err := layered.Value(key)
+		if err != nil {
+			return fmt.Errorf("can`t get config value: %w", err)
+		}
+
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("can`t encode config value: %w", err)
+		}
+
+		fmt.Fprintf(stdout, "%s = %s (%s)\n", key, encoded, layered.Sources[key])
+	}
+
+	return nil
+}
+
+// runWords открывает словарь в редакторе. Без аргументов команды редактора читаются из стандартного ввода,
+// иначе аргументы выполняются как одна команда и словарь сохраняется, если она его изменила.
+func runWords(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	opts := game.DefaultOptions()
+	fs := newFlagSet("words", stderr)
+	addDataFlags(fs, &opts)
+
+	err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	layered, err := game.LoadConfig(opts)
+	if err != nil {
+		return fmt.Errorf("can`t load config: %w", err)
+	}
+
+	ed, err := editor.Open(layered.Config.WordsPath, layered.Config.Difficulties, stdout)
+	if err != nil {
+		return fmt.Errorf("can`t open words editor: %w", err)
+	}
+
+	if layered.Config.DictionaryPath != "" {
+		d, err := game.LoadDictionary(layered.Config.DictionaryPath)
+		if err != nil {
+			return fmt.Errorf("can`t load dictionary: %w", err)
+		}
+
+		ed.SetDictionary(d)
+	}
+
+	if fs.NArg() == 0 {
+		err = ed.Run(stdin)
+		if err != nil {
+			return fmt.Errorf("can`t run words editor: %w", err)
+		}
+
+		return nil
+	}
+
+	err = ed.Exec(fs.Args())
+	if errors.Is(err, editor.ErrUsage) {
+		return fmt.Errorf("%w: %w", errUsage, err)
+	}
+
+	if err != nil {
+		return fmt.Errorf("can`t edit words: %w", err)
+	}
+
+	if ed.Changed() {
+		err = ed.Save()
+		if err != nil {
+			return fmt.Errorf("can`t save words: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// runVersion выводит версию программы.
+func runVersion(args []string, _ io.Reader, stdout, stderr io.Writer) error {
+	err := parseFlags(newFlagSet("version", stderr), args)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(stdout, "hangman "+Version)
+
+	return nil
+}
@@ -0,0 +1,150 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/game"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/dictionary"
+)
+
+// dictUsage - описание подкоманд работы со списком слов.
+const dictUsage = "expected subcommand \"check <слово>...\", \"prefix <префикс>\", \"match <шаблон>\" " +
+	"or \"solve <шаблон> [неверные буквы]\""
+
+// dictMaxArgs - словарь, сопоставляющий подкомандам работы со списком слов наибольшее количество их аргументов.
+// Отрицательное значение не ограничивает количество аргументов.
+var dictMaxArgs = map[string]int{"check": -1, "prefix": 1, "match": 1, "solve": 2}
+
+// runDict выполняет подкоманду поиска по списку слов языка: проверку слов, поиск по префиксу и шаблону
+// и подбор слов и следующей буквы по ходу игры.
+func runDict(args []string, _ io.Reader, stdout, stderr io.Writer) error {
+	opts := game.DefaultOptions()
+	fs := newFlagSet("dict", stderr)
+	addDataFlags(fs, &opts)
+	limit := fs.Int("limit", 20, "наибольшее количество выводимых слов, 0 - без ограничения")
+
+	err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	args = fs.Args()
+	if len(args) == 0 {
+		return fmt.Errorf("%w: %s", errUsage, dictUsage)
+	}
+
+	maxArgs, ok := dictMaxArgs[args[0]]
+	if n := len(args) - 1; !ok || n == 0 || (maxArgs >= 0 && n > maxArgs) {
+		return fmt.Errorf("%w: %s", errUsage, dictUsage)
+	}
+
+	if *limit < 0 {
+		return fmt.Errorf("%w: limit must not be negative, got %d", errUsage, *limit)
+	}
+
+	layered, err := game.LoadConfig(opts)
+	if err != nil {
+		return fmt.Errorf("can`t load config: %w", err)
+	}
+
+	d, err := game.LoadDictionary(layered.Config.DictionaryPath)
+	if errors.Is(err, game.ErrNoDictionary) {
+		return fmt.Errorf("%w: %w", errUsage, err)
+	}
+
+	if err != nil {
+		return fmt.Errorf("can`t load dictionary: %w", err)
+	}
+
+	var found []string
+
+	switch args[0] {
+	case "check":
+		return checkWords(d, args[1:], stdout)
+	case "prefix":
+		found = d.Prefix(args[1])
+	case "match":
+		found, err = d.Match(args[1])
+	case "solve":
+		return solve(d, args[1:], *limit, stdout)
+	}
+
+	if err != nil {
+		return fmt.Errorf("%w: %w", errUsage, err)
+	}
+
+	printWords(found, *limit, stdout)
+
+	return nil
+}
+
+// checkWords выводит для каждого слова, есть ли оно в списке слов.
+// Если какого-либо слова нет, возвращает ошибку, чтобы проверку можно было использовать в скриптах.
+func checkWords(d *dictionary.Dictionary, list []string, stdout io.Writer) error {
+	missing := 0
+
+	for _, word := range list {
+		if d.Contains(word) {
+			fmt.Fprintf(stdout, "%s: есть\n", word)
+			continue
+		}
+
+		fmt.Fprintf(stdout, "%s: нет\n", word)
+
+		missing++
+	}
+
+	if missing != 0 {
+		return fmt.Errorf("%d of %d words are not in the dictionary", missing, len(list))
+	}
+
+	return nil
+}
+
+// solve выводит слова, которые могут быть загаданы при открытых буквах шаблона и неверных догадках,
+// и букву, которую стоит назвать следующей.
+func solve(d *dictionary.Dictionary, args []string, limit int, stdout io.Writer) error {
+	var wrong []rune
+	if len(args) > 1 {
+		wrong = []rune(strings.ToLower(args[1]))
+	}
+
+	candidates, err := d.Candidates(args[0], wrong)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errUsage, err)
+	}
+
+	printWords(candidates, limit, stdout)
+
+	tried := slices.DeleteFunc([]rune(strings.ToLower(args[0])), func(r rune) bool { return r == dictionary.Wildcard })
+	tried = append(tried, wrong...)
+
+	letter, ok := dictionary.Suggest(candidates, tried)
+	if ok {
+		fmt.Fprintf(stdout, "Следующая буква: %c\n", letter)
+	}
+
+	return nil
+}
+
+// printWords выводит не более limit слов и количество невыведенных слов.
+func printWords(list []string, limit int, stdout io.Writer) {
+	shown := list
+	if limit != 0 && len(list) > limit {
+		shown = list[:limit]
+	}
+
+	for _, word := range shown {
+		fmt.Fprintln(stdout, word)
+	}
+
+	if len(shown) < len(list) {
+		fmt.Fprintf(stdout, "… и ещё %d\n", len(list)-len(shown))
+	}
+
+	fmt.Fprintf(stdout, "Найдено слов: %d\n", len(list))
+}
@@ -0,0 +1,11 @@
+package cli
+
+import "github.com/es-debug/backend-academy-2024-go-template/internal/application/game"
+
+// ParseFilter разбирает флаги категорий, тегов и длины слова в параметры запуска.
+func ParseFilter(opts *game.Options, category, tags, length string) error {
+	return parseFilter(opts, category, tags, length)
+}
+
+// ErrUsage - ошибка некорректного использования командной строки.
+var ErrUsage = errUsage
@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"flag"
+	"sort"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/game"
+)
+
+// addDataFlags добавляет в набор флаги путей к файлам игровых данных.
+func addDataFlags(fs *flag.FlagSet, opts *game.Options) {
+	fs.StringVar(&opts.ConfigPath, "config", opts.ConfigPath, "путь к файлу конфига проекта")
+	addOverrideFlag(fs, opts, "words", "wordsPath", "путь к файлу словаря")
+	addOverrideFlag(fs, opts, "frames", "framesPath", "путь к файлу кадров")
+	addOverrideFlag(fs, opts, "themes", "themesPath", "путь к каталогу тем оформления")
+	addOverrideFlag(fs, opts, "dictionary", "dictionaryPath", "путь к списку слов для проверки слов, по одному в строке, возможно .gz")
+}
+
+// addSettingFlags добавляет в набор флаги, переопределяющие настройки игры.
+func addSettingFlags(fs *flag.FlagSet, opts *game.Options) {
+	addOverrideFlag(fs, opts, "lang", "lang", "язык сообщений: ru или en")
+	addOverrideFlag(fs, opts, "color", "color", "цветной вывод: auto, always или never")
+	addOverrideFlag(fs, opts, "frame-delay", "msFrameDelay", "задержка между кадрами анимации в миллисекундах")
+	addOverrideFlag(fs, opts, "transition-delay", "msTransitionDelay", "задержка между кадрами перехода после ошибки в миллисекундах")
+	addOverrideBoolFlag(fs, opts, "reduced-motion", "reducedMotion", "не проигрывать анимации, показывая только последний кадр")
+	addOverrideBoolFlag(fs, opts, "accessible", "accessible", "режим для экранных дикторов: описания вместо рисунков, слово по буквам")
+	addOverrideFlag(fs, opts, "layout-mode", "layoutMode", "исправление ввода в неверной раскладке: ask, convert или off")
+	addOverrideFlag(fs, opts, "selection", "selectionStrategy", "стратегия выбора слова: uniform, weighted или bag")
+	addOverrideFlag(fs, opts, "daily-salt", "dailySalt", "соль ежедневного испытания")
+	addOverrideBoolFlag(fs, opts, "save-replays", "saveReplays", "сохранять записи сыгранных партий")
+	addOverrideFlag(fs, opts, "max-replays", "maxReplays", "наибольшее количество хранимых записей партий, 0 - без ограничения")
+}
+
+// addOverrideFlag добавляет в набор флаг, переопределяющий параметр конфига с указанным ключом.
+func addOverrideFlag(fs *flag.FlagSet, opts *game.Options, name, key, usage string) {
+	fs.Func(name, usage, func(value string) error {
+		opts.Overrides = append(opts.Overrides, game.Override{Key: key, Value: value, Flag: name})
+		return nil
+	})
+}
+
+// addOverrideBoolFlag добавляет в набор логический флаг, переопределяющий параметр конфига с указанным ключом.
+// Флаг без значения устанавливает параметр в true.
+func addOverrideBoolFlag(fs *flag.FlagSet, opts *game.Options, name, key, usage string) {
+	fs.BoolFunc(name, usage, func(value string) error {
+		opts.Overrides = append(opts.Overrides, game.Override{Key: key, Value: value, Flag: name})
+		return nil
+	})
+}
+
+// isFlagSet возвращает true, если флаг с указанным именем был передан в командной строке, иначе false.
+func isFlagSet(fs *flag.FlagSet, name string) bool {
+	isSet := false
+
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			isSet = true
+		}
+	})
+
+	return isSet
+}
+
+// sortedKeys возвращает упорядоченные ключи словаря.
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,316 @@
+package editor
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/schema"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/words"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/loader"
+)
+
+// command хранит описание команды редактора, допустимое количество аргументов и функцию её выполнения.
+// Отрицательное наибольшее количество аргументов не ограничивает их количество.
+type command struct {
+	usage   string
+	minArgs int
+	maxArgs int
+	run     func(e *Editor, args []string) error
+}
+
+// commands - словарь, сопоставляющий именам команд редактора их реализацию.
+var commands = map[string]command{
+	"list": {
+		usage: "list [категория [сложность]] — вывести слова", minArgs: 0, maxArgs: 2, run: (*Editor).list,
+	},
+	"add": {
+		usage: "add <категория> <сложность> <слово> [подсказка] — добавить слово", minArgs: 3, maxArgs: 4, run: (*Editor).add,
+	},
+	"edit": {
+		usage:   "edit <категория> <сложность> <слово> <новое слово> [новая подсказка] — изменить слово и первую подсказку",
+		minArgs: 4, maxArgs: 5, run: (*Editor).edit,
+	},
+	"set": {
+		usage:   "set <категория> <сложность> <слово> <hints|tags|weight|explanation|source> [значения...] — задать или очистить поле",
+		minArgs: 4, maxArgs: -1, run: (*Editor).set,
+	},
+	"remove": {
+		usage: "remove <категория> <сложность> <слово> — удалить слово", minArgs: 3, maxArgs: 3, run: (*Editor).remove,
+	},
+	"move": {
+		usage:   "move <категория> <сложность> <слово> <новая категория> <новая сложность> — перенести слово",
+		minArgs: 5, maxArgs: 5, run: (*Editor).move,
+	},
+	"import": {
+		usage:   "import <файл.csv|файл.tsv> — добавить слова из строк «категория, сложность, слово[, подсказка]»",
+		minArgs: 1, maxArgs: 1, run: (*Editor).importTable,
+	},
+	"dedup": {
+		usage: "dedup — удалить повторяющиеся слова", minArgs: 0, maxArgs: 0, run: (*Editor).dedup,
+	},
+	"save": {
+		usage: "save — сохранить словарь", minArgs: 0, maxArgs: 0, run: func(e *Editor, _ []string) error { return e.Save() },
+	},
+}
+
+// list выводит слова словаря с подсказками, сгруппированные по категориям и уровням сложности.
+// Первый и второй аргументы ограничивают вывод категорией и уровнем сложности.
+func (e *Editor) list(args []string) error {
+	categories := schema.SortedKeys(e.words)
+	if len(args) > 0 {
+		if _, ok := e.words[args[0]]; !ok {
+			return fmt.Errorf("%w %q", words.ErrUnknownCategory, args[0])
+		}
+
+		categories = args[:1]
+	}
+
+	for _, category := range categories {
+		difficulties := schema.SortedKeys(e.words[category])
+		if len(args) > 1 {
+			if _, ok := e.words[category][args[1]]; !ok {
+				return fmt.Errorf("%w %q in category %q", words.ErrUnknownDifficulty, args[1], category)
+			}
+
+			difficulties = args[1:2]
+		}
+
+		for _, difficulty := range difficulties {
+			fmt.Fprintf(e.out, "%s:\n", words.Location{Category: category, Difficulty: difficulty})
+
+			for _, wd := range e.words[category][difficulty] {
+				e.printWord(wd)
+			}
+		}
+	}
+
+	return nil
+}
+
+// add добавляет слово с необязательной подсказкой и проверяет его по списку слов языка.
+func (e *Editor) add(args []string) error {
+	loc, err := e.location(args[0], args[1])
+	if err != nil {
+		return err
+	}
+
+	wd := words.WordData{Word: args[2]}
+	if len(args) > 3 && args[3] != "" {
+		wd.Hints = []string{args[3]}
+	}
+
+	err = e.words.Add(loc, wd)
+	if err != nil {
+		return fmt.Errorf("can`t add word: %w", err)
+	}
+
+	e.changed = true
+
+	fmt.Fprintf(e.out, "Добавлено: %s (%s)\n", wd.Word, loc)
+	e.checkDictionary(wd.Word)
+
+	return nil
+}
+
+// edit заменяет слово и, если она передана, подсказку к нему и проверяет новое слово по списку слов языка.
+func (e *Editor) edit(args []string) error {
+	loc := words.Location{Category: args[0], Difficulty: args[1]}
+
+	old, err := e.words.Get(loc, args[2])
+	if err != nil {
+		return fmt.Errorf("can`t edit word: %w", err)
+	}
+
+	wd := old
+	wd.Word = args[3]
+
+	if len(args) > 4 {
+		wd.Hints = append([]string{args[4]}, wd.Hints[min(1, len(wd.Hints)):]...)
+	}
+
+	err = e.words.Edit(loc, args[2], wd)
+	if err != nil {
+		return fmt.Errorf("can`t edit word: %w", err)
+	}
+
+	e.changed = true
+
+	fmt.Fprintf(e.out, "Изменено: %s (%s)\n", wd.Word, loc)
+	e.checkDictionary(wd.Word)
+
+	return nil
+}
+
+// set задаёт поле данных о слове значениями из оставшихся аргументов или очищает его, если значений нет.
+// Подсказки и теги принимают несколько значений, остальные поля - не более одного.
+func (e *Editor) set(args []string) error {
+	loc := words.Location{Category: args[0], Difficulty: args[1]}
+
+	wd, err := e.words.Get(loc, args[2])
+	if err != nil {
+		return fmt.Errorf("can`t set field: %w", err)
+	}
+
+	field, values := args[3], args[4:]
+
+	if len(values) > 1 && field != "hints" && field != "tags" {
+		return fmt.Errorf("%w: field %q takes a single value", ErrUsage, field)
+	}
+
+	value := strings.Join(values, "")
+
+	switch field {
+	case "hints":
+		wd.Hints = values
+	case "tags":
+		wd.Tags = values
+	case "weight":
+		wd.Weight = 0
+
+		if value != "" {
+			wd.Weight, err = strconv.ParseFloat(value, 64)
+			if err != nil || wd.Weight <= 0 {
+				return fmt.Errorf("%w: weight must be a positive number, got %q", ErrUsage, value)
+			}
+		}
+	case "explanation":
+		wd.Explanation = value
+	case "source":
+		wd.Source = value
+	default:
+		return fmt.Errorf("%w: unknown field %q, expected hints, tags, weight, explanation or source", ErrUsage, field)
+	}
+
+	err = e.words.Edit(loc, args[2], wd)
+	if err != nil {
+		return fmt.Errorf("can`t set field: %w", err)
+	}
+
+	e.changed = true
+
+	e.printWord(wd)
+
+	return nil
+}
+
+// remove удаляет слово.
+func (e *Editor) remove(args []string) error {
+	loc := words.Location{Category: args[0], Difficulty: args[1]}
+
+	wd, err := e.words.Remove(loc, args[2])
+	if err != nil {
+		return fmt.Errorf("can`t remove word: %w", err)
+	}
+
+	e.changed = true
+
+	fmt.Fprintf(e.out, "Удалено: %s (%s)\n", wd.Word, loc)
+
+	return nil
+}
+
+// move переносит слово в другие категорию и уровень сложности.
+func (e *Editor) move(args []string) error {
+	from := words.Location{Category: args[0], Difficulty: args[1]}
+
+	to, err := e.location(args[3], args[4])
+	if err != nil {
+		return err
+	}
+
+	err = e.words.Move(from, args[2], to)
+	if err != nil {
+		return fmt.Errorf("can`t move word: %w", err)
+	}
+
+	e.changed = true
+
+	fmt.Fprintf(e.out, "Перенесено: %s (%s → %s)\n", args[2], from, to)
+
+	return nil
+}
+
+// importTable добавляет слова из файла CSV или TSV. Слова, уже имеющиеся в словаре, пропускаются,
+// а строки с ошибками выводятся и не прерывают импорт остальных строк.
+func (e *Editor) importTable(args []string) error {
+	rows, err := loader.LoadTableFromFile(args[0])
+	if err != nil {
+		return fmt.Errorf("can`t load table: %w", err)
+	}
+
+	added, skipped := 0, 0
+
+	for i, row := range rows {
+		if len(row) < 3 || len(row) > 4 {
+			fmt.Fprintf(e.out, "Запись %d: ожидается 3 или 4 поля, получено %d\n", i+1, len(row))
+			continue
+		}
+
+		err = e.add(row)
+
+		switch {
+		case err == nil:
+			added++
+		case errors.Is(err, words.ErrWordExists):
+			skipped++
+		default:
+			fmt.Fprintf(e.out, "Запись %d: %v\n", i+1, err)
+		}
+	}
+
+	fmt.Fprintf(e.out, "Импортировано слов: %d, пропущено повторов: %d, записей с ошибками: %d\n",
+		added, skipped, len(rows)-added-skipped)
+
+	return nil
+}
+
+// dedup удаляет повторяющиеся слова и выводит удалённые.
+func (e *Editor) dedup(_ []string) error {
+	removed := e.words.Dedup()
+
+	for _, wd := range removed {
+		fmt.Fprintf(e.out, "Удалён повтор: %s\n", wd.Word)
+	}
+
+	if len(removed) != 0 {
+		e.changed = true
+	}
+
+	fmt.Fprintf(e.out, "Удалено повторов: %d\n", len(removed))
+
+	return nil
+}
+
+// help выводит список команд редактора.
+func (e *Editor) help() {
+	for _, name := range schema.SortedKeys(commands) {
+		fmt.Fprintln(e.out, "  "+commands[name].usage)
+	}
+
+	fmt.Fprintln(e.out, "  help — вывести список команд")
+	fmt.Fprintln(e.out, "  quit — выйти из редактора")
+	fmt.Fprintln(e.out, "Аргументы с пробелами заключаются в двойные кавычки")
+}
+
+// printWord выводит слово с подсказками и заполненными полями данных о нём.
+func (e *Editor) printWord(wd words.WordData) {
+	fmt.Fprintf(e.out, "  %s — %s\n", wd.Word, strings.Join(wd.Hints, " / "))
+
+	if len(wd.Tags) != 0 {
+		fmt.Fprintf(e.out, "    теги: %s\n", strings.Join(wd.Tags, ", "))
+	}
+
+	if wd.Weight != 0 {
+		fmt.Fprintf(e.out, "    вес: %g\n", wd.Weight)
+	}
+
+	if wd.Explanation != "" {
+		fmt.Fprintf(e.out, "    пояснение: %s\n", wd.Explanation)
+	}
+
+	if wd.Source != "" {
+		fmt.Fprintf(e.out, "    источник: %s\n", wd.Source)
+	}
+}
@@ -0,0 +1,247 @@
+package editor
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/conditions"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/dictionary"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/schema"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/words"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/loader"
+)
+
+var (
+	// ErrUsage - неизвестная команда редактора или неверное количество её аргументов.
+	ErrUsage = errors.New("invalid command")
+	// ErrIncomplete - в категории словаря нет слов какого-либо уровня сложности конфига.
+	ErrIncomplete = errors.New("words are incomplete")
+)
+
+// Editor хранит редактируемый словарь, путь к его файлу, уровни сложности конфига
+// и необязательный список слов языка для проверки добавляемых слов.
+type Editor struct {
+	path         string
+	words        words.Words
+	difficulties conditions.Difficulties
+	dictionary   *dictionary.Dictionary
+	changed      bool
+	out          io.Writer
+}
+
+// Open загружает словарь из файла по указанному path для редактирования. Уровни сложности difficulties
+// ограничивают уровни сложности, в которые можно добавлять слова. Сообщения редактора выводятся в out.
+func Open(path string, difficulties conditions.Difficulties, out io.Writer) (*Editor, error) {
+	var raw any
+
+	err := loader.LoadDataFromFile(path, &raw)
+	if err != nil {
+		return nil, fmt.Errorf("can`t load words from file: %w", err)
+	}
+
+	problems := words.Validate(raw, path)
+	if len(problems) != 0 {
+		return nil, fmt.Errorf("invalid words:\n%w", problems)
+	}
+
+	e := &Editor{
+		path:         path,
+		words:        make(words.Words),
+		difficulties: difficulties,
+		out:          out,
+	}
+
+	err = loader.Decode(raw, &e.words)
+	if err != nil {
+		return nil, fmt.Errorf("can`t decode words: %w", err)
+	}
+
+	return e, nil
+}
+
+// SetDictionary задаёт список слов языка. Добавляемые и изменяемые слова, которых нет в нём, выводятся предупреждениями:
+// такие слова сохраняются, так как имена собственные и редкие слова могут отсутствовать в списке.
+func (e *Editor) SetDictionary(d *dictionary.Dictionary) {
+	e.dictionary = d
+}
+
+// Changed возвращает true, если в словаре есть несохранённые изменения, иначе false.
+func (e *Editor) Changed() bool {
+	return e.changed
+}
+
+// Run читает команды из in по одной в строке и выполняет их, пока не будет введена команда quit или не закончится ввод.
+// Ошибки команд выводятся и не прерывают работу. Выход с несохранёнными изменениями требует повторной команды quit.
+func (e *Editor) Run(in io.Reader) error {
+	scanner := bufio.NewScanner(in)
+	confirmQuit := false
+
+	fmt.Fprintln(e.out, "Редактор словаря "+e.path+". Введите help для списка команд")
+
+	for {
+		fmt.Fprint(e.out, "> ")
+
+		if !scanner.Scan() {
+			break
+		}
+
+		args, err := splitArgs(scanner.Text())
+		if err != nil {
+			fmt.Fprintf(e.out, "Ошибка: %v\n", err)
+			continue
+		}
+
+		if len(args) == 0 {
+			continue
+		}
+
+		if args[0] == "quit" || args[0] == "exit" {
+			if !e.changed || confirmQuit {
+				return nil
+			}
+
+			confirmQuit = true
+
+			fmt.Fprintln(e.out, "Есть несохранённые изменения. Введите save, чтобы сохранить их, или quit ещё раз, чтобы выйти")
+
+			continue
+		}
+
+		confirmQuit = false
+
+		err = e.Exec(args)
+		if err != nil {
+			fmt.Fprintf(e.out, "Ошибка: %v\n", err)
+		}
+	}
+
+	fmt.Fprintln(e.out)
+
+	if e.changed {
+		fmt.Fprintln(e.out, "Изменения не сохранены")
+	}
+
+	return scanner.Err()
+}
+
+// Exec выполняет команду редактора, первым элементом args которой является её имя.
+// Неизвестная команда и неверное количество аргументов возвращаются как ErrUsage.
+func (e *Editor) Exec(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: expected command", ErrUsage)
+	}
+
+	if args[0] == "help" {
+		e.help()
+		return nil
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		return fmt.Errorf("%w: unknown command %q, type help for the list of commands", ErrUsage, args[0])
+	}
+
+	n := len(args) - 1
+	if n < cmd.minArgs || (cmd.maxArgs >= 0 && n > cmd.maxArgs) {
+		return fmt.Errorf("%w: %s", ErrUsage, cmd.usage)
+	}
+
+	return cmd.run(e, args[1:])
+}
+
+// Save записывает словарь в его файл в формате, определённом по расширению, с упорядоченными ключами.
+// Словарь, в категории которого нет слов какого-либо уровня сложности конфига, не пройдёт проверку при запуске игры,
+// поэтому не сохраняется: возвращается ErrIncomplete со списком недостающих уровней сложности.
+func (e *Editor) Save() error {
+	var missing []string
+
+	for _, category := range schema.SortedKeys(e.words) {
+		for _, difficulty := range schema.SortedKeys(e.difficulties) {
+			if _, ok := e.words[category][difficulty]; !ok {
+				missing = append(missing, fmt.Sprintf("category %q has no words of difficulty %q", category, difficulty))
+			}
+		}
+	}
+
+	if len(missing) != 0 {
+		return fmt.Errorf("%w: %s", ErrIncomplete, strings.Join(missing, "; "))
+	}
+
+	err := loader.SaveDataToFile(e.path, e.words)
+	if err != nil {
+		return fmt.Errorf("can`t save words to file: %w", err)
+	}
+
+	e.changed = false
+
+	fmt.Fprintln(e.out, "Словарь сохранён: "+e.path)
+
+	return nil
+}
+
+// checkDictionary выводит предупреждение, если задан список слов языка и слова в нём нет.
+func (e *Editor) checkDictionary(word string) {
+	if e.dictionary != nil && !e.dictionary.Contains(word) {
+		fmt.Fprintf(e.out, "Предупреждение: слова %q нет в списке слов\n", word)
+	}
+}
+
+// location возвращает расположение слова, проверяя, что уровень сложности задан в конфиге.
+func (e *Editor) location(category, difficulty string) (words.Location, error) {
+	if _, ok := e.difficulties[difficulty]; !ok && e.difficulties != nil {
+		return words.Location{}, fmt.Errorf("%w %q, expected one of %v",
+			words.ErrUnknownDifficulty, difficulty, schema.SortedKeys(e.difficulties))
+	}
+
+	return words.Location{Category: category, Difficulty: difficulty}, nil
+}
+
+// splitArgs разбивает строку команды на аргументы, разделённые пробелами.
+// Аргумент, содержащий пробелы, заключается в двойные кавычки; кавычка внутри него записывается как \".
+func splitArgs(line string) ([]string, error) {
+	var (
+		args    []string
+		current strings.Builder
+		inArg   bool
+		quoted  bool
+		escaped bool
+	)
+
+	for _, r := range line {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+
+			escaped = false
+		case quoted && r == '\\':
+			escaped = true
+		case r == '"':
+			quoted = !quoted
+			inArg = true
+		case !quoted && (r == ' ' || r == '\t'):
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+
+			inArg = true
+		}
+	}
+
+	if quoted {
+		return nil, fmt.Errorf("%w: unterminated quote", ErrUsage)
+	}
+
+	if inArg {
+		args = append(args, current.String())
+	}
+
+	return args, nil
+}
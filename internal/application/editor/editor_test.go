@@ -0,0 +1,69 @@
+package editor_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/editor"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/conditions"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/words"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/loader"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "words.json")
+	table := filepath.Join(dir, "words.tsv")
+
+	assert.NoError(t, loader.SaveDataToFile(path, words.Words{
+		"пища": {"лёгкая": {{Word: "Хлеб", Hints: []string{"Всему голова!"}}}},
+	}))
+	assert.NoError(t, os.WriteFile(table, []byte("# категория\tсложность\tслово\tподсказка\n"+
+		"пища\tлёгкая\tЩи\t\"Суп\" (С)\nпища\tлёгкая\tхлеб\n"), 0o600))
+
+	var out bytes.Buffer
+
+	ed, err := editor.Open(path, conditions.Difficulties{"лёгкая": 7, "трудная": 3}, &out)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	input := strings.Join([]string{
+		`add "пища" трудная Борщ "Суп со свёклой"`,
+		"import " + table,
+		"move пища трудная борщ персонажи трудная",
+		"add пища средняя Окрошка",
+		"save",
+		"add пища трудная Оливье",
+		"add персонажи лёгкая R2-D2",
+		"save",
+		"quit",
+	}, "\n")
+
+	assert.NoError(t, ed.Run(strings.NewReader(input)))
+	assert.Contains(t, out.String(), "unknown difficulty")
+	assert.Contains(t, out.String(), `category "персонажи" has no words of difficulty "лёгкая"`)
+	assert.Contains(t, out.String(), `category "пища" has no words of difficulty "трудная"`)
+	assert.False(t, ed.Changed())
+
+	var ws words.Words
+
+	assert.NoError(t, loader.LoadDataFromFile(path, &ws))
+	assert.Equal(t, words.Words{
+		"пища": {
+			"лёгкая": {
+				{Word: "Хлеб", Hints: []string{"Всему голова!"}},
+				{Word: "Щи", Hints: []string{`"Суп" (С)`}},
+			},
+			"трудная": {{Word: "Оливье"}},
+		},
+		"персонажи": {
+			"лёгкая":  {{Word: "R2-D2"}},
+			"трудная": {{Word: "Борщ", Hints: []string{"Суп со свёклой"}}},
+		},
+	}, ws)
+}
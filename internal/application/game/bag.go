@@ -0,0 +1,40 @@
+package game
+
+import (
+	"fmt"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/words"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/loader"
+)
+
+// loadBag возвращает сохранённое состояние выбора слов без повторов или пустое состояние, если игр ещё не было.
+func loadBag() (*words.Bag, error) {
+	bag := words.NewBag()
+
+	bagPath, err := dataPath("bag.json")
+	if err != nil {
+		return nil, fmt.Errorf("can`t get bag path: %w", err)
+	}
+
+	err = loadIfExists(bagPath, bag)
+	if err != nil {
+		return nil, fmt.Errorf("can`t load bag: %w", err)
+	}
+
+	return bag, nil
+}
+
+// saveBag сохраняет состояние выбора слов без повторов.
+func saveBag(bag *words.Bag) error {
+	bagPath, err := dataPath("bag.json")
+	if err != nil {
+		return fmt.Errorf("can`t get bag path: %w", err)
+	}
+
+	err = loader.SaveDataToFile(bagPath, bag)
+	if err != nil {
+		return fmt.Errorf("can`t save bag to file: %w", err)
+	}
+
+	return nil
+}
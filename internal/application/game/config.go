@@ -0,0 +1,107 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/schema"
+)
+
+// LoadConfig собирает конфиг из слоёв в порядке возрастания приоритета: настройки по-умолчанию,
+// пользовательский файл в каталоге конфигурации, файл проекта, переменные окружения HANGMAN_* и флаги командной строки.
+// Нарушения схемы во всех слоях и недопустимые итоговые значения возвращаются вместе списком schema.Problems.
+func LoadConfig(opts Options) (config.Layered, error) {
+	var problems schema.Problems
+
+	layered := config.NewLayered()
+
+	userPath, err := userConfigPath()
+	if err != nil {
+		return layered, fmt.Errorf("can`t get user config path: %w", err)
+	}
+
+	err = collect(&problems, applyFile(&layered, userPath, false))
+	if err != nil {
+		return layered, fmt.Errorf("can`t apply user config: %w", err)
+	}
+
+	err = collect(&problems, applyFile(&layered, opts.ConfigPath, true))
+	if err != nil {
+		return layered, fmt.Errorf("can`t apply project config: %w", err)
+	}
+
+	_ = collect(&problems, layered.ApplyEnv(os.LookupEnv))
+
+	for _, o := range opts.Overrides {
+		_ = collect(&problems, layered.Set(o.Key, o.Value, "флаг --"+o.Flag))
+	}
+
+	problems = append(problems, layered.Validate()...)
+
+	return layered, problems.Err()
+}
+
+// userConfigPath возвращает путь к первому существующему пользовательскому файлу конфига
+// среди config.json, config.yaml, config.yml и config.toml или путь к config.json, если ни одного нет.
+func userConfigPath() (string, error) {
+	var first string
+
+	for _, name := range []string{"config.json", "config.yaml", "config.yml", "config.toml"} {
+		path, err := dataPath(name)
+		if err != nil {
+			return "", err
+		}
+
+		if _, err = os.Stat(path); err == nil {
+			return path, nil
+		}
+
+		if first == "" {
+			first = path
+		}
+	}
+
+	return first, nil
+}
+
+// applyFile применяет к конфигу файл по указанному path. Отсутствующий необязательный файл пропускается.
+func applyFile(layered *config.Layered, path string, required bool) error {
+	var raw any
+
+	load := loadIfExists
+	if required {
+		load = loadFile
+	}
+
+	err := load(path, &raw)
+	if err != nil || raw == nil {
+		return err
+	}
+
+	var problems schema.Problems
+
+	data, ok := schema.Object(&problems, fileSource(path), schema.Root, raw)
+	if !ok {
+		return problems
+	}
+
+	return layered.ApplyMap(data, fileSource(path))
+}
+
+// fileSource возвращает описание файла как источника данных.
+func fileSource(path string) string {
+	return "файл " + path
+}
+
+// collect добавляет нарушения схемы из err в problems и возвращает err, только если это ошибка другого рода.
+func collect(problems *schema.Problems, err error) error {
+	var ps schema.Problems
+	if errors.As(err, &ps) {
+		*problems = append(*problems, ps...)
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,80 @@
+package game
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/frames"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/loader"
+)
+
+// ConvertFile перекодирует файл данных из формата src в формат dst, определяя форматы по расширениям.
+// Кадры набора кадров записываются в JSON массивами строк, а в YAML и TOML - многострочными строками,
+// чтобы их было удобно редактировать. Кадры с задержкой или описанием записываются объектами.
+func ConvertFile(src, dst string) error {
+	var (
+		raw  any
+		data any
+	)
+
+	err := loader.LoadDataFromFile(src, &raw)
+	if err != nil {
+		return fmt.Errorf("can`t load data from file: %w", err)
+	}
+
+	data = raw
+
+	if len(frames.Validate(raw, src)) == 0 {
+		data, err = convertFrames(raw, strings.EqualFold(filepath.Ext(dst), ".json"))
+		if err != nil {
+			return fmt.Errorf("can`t convert frames: %w", err)
+		}
+	}
+
+	err = loader.SaveDataToFile(dst, data)
+	if err != nil {
+		return fmt.Errorf("can`t save data to file: %w", err)
+	}
+
+	return nil
+}
+
+// convertFrames возвращает набор кадров, в котором кадры записаны массивами строк или многострочными строками.
+func convertFrames(raw any, asLines bool) (any, error) {
+	var sfm frames.StageFramesMap
+
+	err := loader.Decode(raw, &sfm)
+	if err != nil {
+		return nil, err
+	}
+
+	if asLines {
+		return sfm, nil
+	}
+
+	blocks := make(map[string][]any, len(sfm))
+
+	for stage, frs := range sfm {
+		for _, fr := range frs {
+			if fr.MsDelay == 0 && fr.Description == "" {
+				blocks[stage] = append(blocks[stage], fr.String())
+				continue
+			}
+
+			block := map[string]any{"lines": fr.String()}
+
+			if fr.MsDelay != 0 {
+				block["msDelay"] = fr.MsDelay
+			}
+
+			if fr.Description != "" {
+				block["description"] = fr.Description
+			}
+
+			blocks[stage] = append(blocks[stage], block)
+		}
+	}
+
+	return blocks, nil
+}
@@ -0,0 +1,50 @@
+package game_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/game"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/loader"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertFileKeepsIntegers(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "config.json")
+
+	assert.NoError(t, os.WriteFile(src, []byte(`{"difficulties": {"лёгкая": 7}, "framesInAnimation": 4, "speed": 1.5}`), 0o600))
+
+	tests := []struct {
+		name     string
+		expected []string
+	}{
+		{name: "config.toml", expected: []string{"framesInAnimation = 4\n", `"лёгкая" = 7` + "\n", "speed = 1.5\n"}},
+		{name: "config.yaml", expected: []string{"framesInAnimation: 4\n", "лёгкая: 7\n", "speed: 1.5\n"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dst := filepath.Join(dir, tt.name)
+
+			assert.NoError(t, game.ConvertFile(src, dst))
+
+			encoded, err := os.ReadFile(dst)
+			assert.NoError(t, err)
+
+			for _, line := range tt.expected {
+				assert.Contains(t, string(encoded), line)
+			}
+
+			back := filepath.Join(dir, tt.name+".json")
+			assert.NoError(t, game.ConvertFile(dst, back))
+
+			var expected, actual any
+
+			assert.NoError(t, loader.LoadDataFromFile(src, &expected))
+			assert.NoError(t, loader.LoadDataFromFile(back, &actual))
+			assert.Equal(t, expected, actual)
+		})
+	}
+}
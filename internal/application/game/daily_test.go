@@ -0,0 +1,33 @@
+package game_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/game"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunDailyTimezones(t *testing.T) {
+	wordsPath := setupData(t)
+
+	// Местные даты разные, но день UTC один: 2024-09-30.
+	moscow := time.Date(2024, time.October, 1, 1, 30, 0, 0, time.FixedZone("MSK", 3*60*60))
+	losAngeles := time.Date(2024, time.September, 30, 16, 30, 0, 0, time.FixedZone("PDT", -7*60*60))
+
+	runDaily := func(date time.Time) func(*game.Game, context.Context) error {
+		return func(g *game.Game, ctx context.Context) error {
+			return g.RunDaily(ctx, date)
+		}
+	}
+
+	out, err := playScript(t, wordsPath, nil, runDaily(moscow), step{expect: letterPrompt, line: "кот"})
+	assert.NoError(t, err)
+	assert.Contains(t, out, "испытание дня 2024-09-30")
+
+	out, err = playScript(t, wordsPath, nil, runDaily(losAngeles))
+	assert.NoError(t, err)
+	assert.Contains(t, out, "Испытание дня уже сыграно")
+	assert.Contains(t, out, "испытание дня 2024-09-30")
+}
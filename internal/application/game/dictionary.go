@@ -0,0 +1,48 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/dictionary"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/loader"
+)
+
+// ErrNoDictionary - путь к списку слов не задан в конфиге.
+var ErrNoDictionary = errors.New("dictionary is not configured")
+
+// LoadDictionary загружает список слов из файла по указанному path. Если путь не задан, возвращает ErrNoDictionary.
+func LoadDictionary(path string) (*dictionary.Dictionary, error) {
+	if path == "" {
+		return nil, fmt.Errorf("%w: set dictionaryPath or --dictionary", ErrNoDictionary)
+	}
+
+	list, err := loader.LoadWordListFromFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("can`t load word list from file: %w", err)
+	}
+
+	return dictionary.New(list), nil
+}
+
+// useDictionary задаёт сессии список слов языка, если он задан в конфиге, чтобы неверные слова, называемые целиком,
+// проверялись по нему, и проверяет по нему слово, заданное игроком. Без списка слова не проверяются.
+func (g *Game) useDictionary() error {
+	if g.config.DictionaryPath == "" {
+		return nil
+	}
+
+	d, err := LoadDictionary(g.config.DictionaryPath)
+	if err != nil {
+		return err
+	}
+
+	g.session.SetDictionary(d)
+
+	if g.options.Word != "" && !d.Contains(g.options.Word) {
+		return &dictionary.WordError{Word: strings.ToLower(g.options.Word), Err: dictionary.ErrUnknownWord}
+	}
+
+	return nil
+}
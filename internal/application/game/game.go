@@ -1,27 +1,44 @@
 package game
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
 
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/config"
-	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/frames"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/daily"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/random"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/replay"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/schema"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/session"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/stats"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/theme"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/words"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/console"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/loader"
 )
 
-// Game хранит конфиг, словарь, кадры и сессию.
+// ErrDisplayed - ошибка уже выведена пользователю в игровой консоли и не должна выводиться повторно.
+var ErrDisplayed = errors.New("error is displayed")
+
+// Game хранит параметры запуска, конфиг, словарь, темы оформления и сессию.
 type Game struct {
-	config         config.Config
-	words          words.Words
-	stageFramesMap frames.StageFramesMap
-	session        session.Session
+	options Options
+	config  config.Config
+	words   words.Words
+	themes  theme.Themes
+	session session.Session
 }
 
-// New возвращает инициализированную структуру Game.
-func New() (Game, error) {
-	g := Game{}
+// New возвращает инициализированную структуру Game с переданными параметрами запуска.
+func New(opts Options) (Game, error) {
+	g := Game{options: opts}
 
 	err := g.loadGameData()
 	if err != nil {
@@ -31,39 +48,448 @@ func New() (Game, error) {
 	return g, nil
 }
 
-// Run запускает игру.
-func (g *Game) Run() error {
-	g.session = session.New(console.New())
+// Run запускает игру. Если есть сохранённая прерванная партия того же режима, пользователю предлагается продолжить её,
+// кроме игры с заданными зерном или словом.
+// Отмена ctx прерывает партию, как и конец ввода. После сигнала прерванную партию можно сохранить, чтобы продолжить
+// её при следующем запуске, а конец ввода завершает игру, ничего не сохраняя.
+func (g *Game) Run(ctx context.Context) error {
+	gc, err := g.newConsole()
+	if err != nil {
+		return fmt.Errorf("can`t create console: %w", err)
+	}
+
+	rec := newRecorder(gc)
+
+	var rp *replay.Replay
+
+	if g.options.Random == nil && g.options.Word == "" {
+		rp, err = g.resume(rec, "")
+		if err != nil {
+			return displayError(gc, fmt.Errorf("can`t resume game: %w", err))
+		}
+	}
+
+	if rp != nil {
+		g.session = session.New(newResumer(rec, rp.Inputs), random.New(rp.Seed))
+		g.session.PresetReplay(*rp)
+	} else {
+		rnd, err := g.randomSource()
+		if err != nil {
+			return fmt.Errorf("can`t create random source: %w", err)
+		}
+
+		g.session = session.New(rec, rnd)
+	}
+
+	if g.options.Practice {
+		g.session.EnablePractice()
+	}
 
-	err := g.session.Play(g.words, g.config.Difficulties, g.config.RandomSelectionCommand, g.config.MsFrameDelay, g.stageFramesMap)
+	_, err = g.play(ctx, rec, "")
 	if err != nil {
-		return fmt.Errorf("can`t play session: %w", err)
+		return displayError(gc, err)
+	}
+
+	return nil
+}
+
+// RunDaily запускает ежедневное испытание на указанную дату, если оно ещё не было сыграно. Дата берётся в UTC.
+// Прерванное и сохранённое испытание можно продолжить. Испытание считается сыгранным, только если партия завершена.
+func (g *Game) RunDaily(ctx context.Context, date time.Time) error {
+	recordPath, err := dataPath("daily.json")
+	if err != nil {
+		return fmt.Errorf("can`t get daily record path: %w", err)
+	}
+
+	record := make(daily.Record)
+
+	err = loadIfExists(recordPath, &record)
+	if err != nil {
+		return fmt.Errorf("can`t load daily record: %w", err)
+	}
+
+	challenge := daily.New(date, g.config.DailySalt)
+
+	gc, err := g.newConsole()
+	if err != nil {
+		return fmt.Errorf("can`t create console: %w", err)
+	}
+
+	if record.IsPlayed(challenge) {
+		gc.DisplayDailyPlayed(record[challenge.Key()])
+		return nil
+	}
+
+	rec := newRecorder(gc)
+
+	rp, err := g.resume(rec, challenge.Key())
+	if err != nil {
+		return displayError(gc, fmt.Errorf("can`t resume daily session: %w", err))
+	}
+
+	if rp != nil {
+		g.session = session.NewDaily(newResumer(rec, rp.Inputs), challenge)
+		g.session.PresetReplay(*rp)
+	} else {
+		g.session = session.NewDaily(rec, challenge)
+	}
+
+	finished, err := g.play(ctx, rec, challenge.Key())
+	if err != nil {
+		return displayError(gc, fmt.Errorf("can`t play daily session: %w", err))
+	}
+
+	if !finished {
+		return nil
+	}
+
+	record.Add(challenge, g.session.Summary())
+
+	err = loader.SaveDataToFile(recordPath, record)
+	if err != nil {
+		return fmt.Errorf("can`t save daily record to file: %w", err)
 	}
 
 	return nil
 }
 
+// displayError выводит ошибку err в игровой консоли gc и возвращает её, обёрнутую в ErrDisplayed.
+func displayError(gc *console.GameConsole, err error) error {
+	gc.DisplayError(err)
+	return fmt.Errorf("%w: %w", ErrDisplayed, err)
+}
+
+// randomSource возвращает источник случайных чисел из параметров запуска или генератор со случайным зерном.
+func (g *Game) randomSource() (random.Source, error) {
+	if g.options.Random != nil {
+		return g.options.Random, nil
+	}
+
+	seed, err := random.NewSeed()
+	if err != nil {
+		return nil, fmt.Errorf("can`t generate seed: %w", err)
+	}
+
+	return random.New(seed), nil
+}
+
+// newConsole возвращает игровую консоль с настройками вывода из конфига.
+func (g *Game) newConsole() (*console.GameConsole, error) {
+	return console.New(g.consoleOptions())
+}
+
+// consoleOptions возвращает настройки вывода консоли из конфига и параметров запуска.
+func (g *Game) consoleOptions() console.Options {
+	return console.Options{
+		Lang:          g.config.Lang,
+		Color:         g.config.Color,
+		ReducedMotion: g.config.ReducedMotion,
+		Accessible:    g.config.Accessible,
+		Practice:      g.options.Practice,
+		Input:         g.options.Input,
+		Output:        g.options.Output,
+	}
+}
+
+// Categories возвращает упорядоченный список категорий словаря.
+func (g *Game) Categories() []string {
+	categories := make([]string, 0, len(g.words))
+	for category := range g.words {
+		categories = append(categories, category)
+	}
+
+	sort.Strings(categories)
+
+	return categories
+}
+
+// LoadStats возвращает сохранённую статистику игр или пустую статистику, если игр ещё не было.
+func LoadStats() (stats.Stats, error) {
+	st := stats.New()
+
+	statsPath, err := dataPath("stats.json")
+	if err != nil {
+		return st, fmt.Errorf("can`t get stats path: %w", err)
+	}
+
+	err = loadIfExists(statsPath, &st)
+	if err != nil {
+		return st, fmt.Errorf("can`t load stats: %w", err)
+	}
+
+	return st, nil
+}
+
+// play задаёт сессии условия из параметров запуска и проигрывает её. Результаты завершённой партии,
+// в том числе собранный из событий сессии результат для статистики, сохраняются finish.
+// Прерванная партия обрабатывается interrupt. dailyKey - ключ ежедневного испытания или пустая строка.
+// Возвращает признак завершения партии.
+func (g *Game) play(ctx context.Context, rec *recorder, dailyKey string) (bool, error) {
+	seen, err := loadSeen()
+	if err != nil {
+		return false, fmt.Errorf("can`t load seen words: %w", err)
+	}
+
+	g.session.SetSeen(seen)
+
+	bag, err := g.selector()
+	if err != nil {
+		return false, fmt.Errorf("can`t create word selector: %w", err)
+	}
+
+	err = g.preset(seen)
+	if err != nil {
+		return false, err
+	}
+
+	var result outcome
+
+	err = g.session.Events().Subscribe(result.handle)
+	if err != nil {
+		return false, fmt.Errorf("can`t subscribe to session events: %w", err)
+	}
+
+	rec.SetContext(ctx)
+
+	defer rec.Flush()
+	defer g.session.Events().Close()
+
+	err = g.session.Play(
+		ctx,
+		g.words,
+		g.config.Difficulties,
+		g.config.RandomSelectionCommand,
+		g.config.MsFrameDelay,
+		g.config.MsTransitionDelay,
+		g.themes,
+	)
+	if err != nil {
+		return false, g.interrupt(err, rec, dailyKey)
+	}
+
+	err = g.finish(rec, seen, bag, result)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// finish сохраняет результаты завершённой партии: запись игры с вводом, записанным rec, если это не отключено в конфиге,
+// а если игра не тренировочная и слово не задано игроком, также загаданное слово в множестве встречавшихся слов seen
+// и в состоянии выбора без повторов bag, если оно используется, и результат result в статистике.
+// Своё слово игрок знает заранее, поэтому такая партия, как и тренировочная, не учитывается.
+func (g *Game) finish(rec *recorder, seen map[string]struct{}, bag *words.Bag, result outcome) error {
+	if g.config.SaveReplays {
+		path, err := g.saveReplay(rec)
+		if err != nil {
+			return fmt.Errorf("can`t save replay: %w", err)
+		}
+
+		rec.DisplayReplaySaved(path)
+	}
+
+	if g.options.Practice || g.options.Word != "" {
+		return nil
+	}
+
+	err := saveSeen(seen, g.session.Word())
+	if err != nil {
+		return fmt.Errorf("can`t save seen words: %w", err)
+	}
+
+	if bag != nil {
+		err = saveBag(bag)
+		if err != nil {
+			return fmt.Errorf("can`t save bag: %w", err)
+		}
+	}
+
+	err = saveStats(result)
+	if err != nil {
+		return fmt.Errorf("can`t save stats: %w", err)
+	}
+
+	return nil
+}
+
+// preset задаёт сессии условия игры из параметров запуска: фильтр слов, из которого по выбору пользователя исключаются
+// встречавшиеся слова seen, категорию, уровень сложности, тему, слово игрока, список слов языка и раскладки клавиатуры.
+func (g *Game) preset(seen map[string]struct{}) error {
+	query := g.options.Filter
+	if g.options.Unseen {
+		query.Exclude = seen
+	}
+
+	if g.options.Category != "" {
+		query.Categories = append(query.Categories, g.options.Category)
+	}
+
+	err := g.useDictionary()
+	if err != nil {
+		return fmt.Errorf("can`t use dictionary: %w", err)
+	}
+
+	if g.options.Word != "" {
+		g.session.PresetWord(g.options.Word)
+	}
+
+	if query.HasFilters() {
+		g.session.PresetQuery(query)
+	} else if g.options.Category != "" {
+		g.session.PresetCategory(g.options.Category)
+	}
+
+	if g.options.Difficulty != "" {
+		g.session.PresetDifficulty(g.options.Difficulty)
+	}
+
+	if g.options.Theme != "" {
+		g.session.PresetTheme(g.options.Theme)
+	}
+
+	g.session.SetKeyboardLayouts(g.config.LayoutMode, g.config.KeyboardLayouts)
+
+	return nil
+}
+
+// selector задаёт сессии стратегию выбора слова из конфига и возвращает состояние выбора без повторов,
+// если оно используется и должно быть сохранено после игры, иначе nil. Игра с заданным зерном выбирает слово по весу
+// без учёта сохранённого состояния, чтобы её можно было воспроизвести.
+func (g *Game) selector() (*words.Bag, error) {
+	strategy := g.config.SelectionStrategy
+	if strategy == words.StrategyBag && g.options.Random != nil {
+		strategy = words.StrategyWeighted
+	}
+
+	var bag *words.Bag
+
+	if strategy == words.StrategyBag {
+		var err error
+
+		bag, err = loadBag()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	selector, err := words.NewSelector(strategy, bag)
+	if err != nil {
+		return nil, err
+	}
+
+	g.session.SetSelector(selector)
+
+	return bag, nil
+}
+
+// interrupt обрабатывает прерывание партии ошибкой err. Конец ввода завершает игру, ничего не сохраняя.
+// После SIGTERM партия сохраняется без вопроса, а после SIGINT - если пользователь согласится, чтобы её можно было
+// продолжить при следующем запуске. Партия, условия которой ещё не выбраны, не сохраняется.
+// Ошибки, не связанные с прерыванием, возвращаются обёрнутыми.
+func (g *Game) interrupt(err error, rec *recorder, dailyKey string) error {
+	var sigErr *SignalError
+
+	switch {
+	case errors.Is(err, io.EOF):
+		rec.DisplayInterrupted()
+		return nil
+	case errors.As(err, &sigErr):
+		save := true
+
+		if sigErr.Signal == os.Interrupt {
+			save, err = rec.ConfirmSave()
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			if err != nil {
+				return fmt.Errorf("can`t confirm save: %w", err)
+			}
+		} else {
+			rec.DisplayInterrupted()
+		}
+
+		if !save || !g.session.IsConfigured() {
+			return nil
+		}
+
+		return g.suspend(rec, dailyKey)
+	default:
+		return fmt.Errorf("can`t play session: %w", err)
+	}
+}
+
 // loadGameData инициализирует данные об игре, загружая их из файлов.
+// Нарушения схемы во всех файлах возвращаются вместе списком schema.Problems.
 func (g *Game) loadGameData() error {
-	g.config = config.New()
+	var problems schema.Problems
 
-	err := loader.LoadDataFromFile("./internal/infrastructure/files/config.json", &g.config)
+	layered, err := LoadConfig(g.options)
+
+	err = collect(&problems, err)
 	if err != nil {
-		return fmt.Errorf("can`t load config from file: %w", err)
+		return fmt.Errorf("can`t load config: %w", err)
 	}
 
-	g.words = make(words.Words)
+	g.config = layered.Config
+
+	var rawWords any
 
-	err = loader.LoadDataFromFile("./internal/infrastructure/files/words.json", &g.words)
+	err = loadFile(g.config.WordsPath, &rawWords)
 	if err != nil {
 		return fmt.Errorf("can`t load words from file: %w", err)
 	}
 
-	g.stageFramesMap = frames.New(g.config.FramesInAnimation)
+	wordsProblems := words.Validate(rawWords, fileSource(g.config.WordsPath))
+	problems = append(problems, wordsProblems...)
 
-	err = loader.LoadDataFromFile("./internal/infrastructure/files/frames.json", &g.stageFramesMap)
+	if len(wordsProblems) == 0 {
+		err = loader.Decode(rawWords, &g.words)
+		if err != nil {
+			return fmt.Errorf("can`t decode words: %w", err)
+		}
+
+		problems = append(problems, g.validateWords()...)
+	}
+
+	err = g.loadThemes(&problems)
+	if err != nil {
+		return fmt.Errorf("can`t load themes: %w", err)
+	}
+
+	if len(problems) != 0 {
+		return fmt.Errorf("invalid game data:\n%w", problems)
+	}
+
+	return nil
+}
+
+// dataPath возвращает путь к файлу с указанным именем в пользовательском каталоге конфигурации.
+func dataPath(name string) (string, error) {
+	dir, err := os.UserConfigDir()
 	if err != nil {
-		return fmt.Errorf("can`t load stageFramesMap from file: %w", err)
+		return "", fmt.Errorf("can`t get user config dir: %w", err)
+	}
+
+	return filepath.Join(dir, "hangman", name), nil
+}
+
+// loadFile загружает данные из файла по указанному path.
+func loadFile(path string, target any) error {
+	err := loader.LoadDataFromFile(path, target)
+	if err != nil {
+		return fmt.Errorf("can`t load data from file: %w", err)
+	}
+
+	return nil
+}
+
+// loadIfExists загружает данные из файла по указанному path, если он существует, иначе оставляет target без изменений.
+func loadIfExists(path string, target any) error {
+	err := loader.LoadDataFromFile(path, target)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("can`t load data from file: %w", err)
 	}
 
 	return nil
@@ -0,0 +1,60 @@
+package game_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/game"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunSavesResults(t *testing.T) {
+	tests := []struct {
+		name      string
+		configure func(opts *game.Options)
+		steps     []step
+		saved     bool
+	}{
+		{
+			name:      "dictionary word",
+			configure: withOverrides(game.Override{Key: "selectionStrategy", Value: "bag"}),
+			steps:     []step{{expect: letterPrompt, line: "кот"}},
+			saved:     true,
+		},
+		{
+			name: "practice",
+			configure: func(opts *game.Options) {
+				opts.Practice = true
+				withOverrides(game.Override{Key: "selectionStrategy", Value: "bag"})(opts)
+			},
+			steps: []step{{expect: letterPrompt, line: "кот"}, {expect: "Отменить последнюю попытку?", line: "н"}},
+		},
+		{
+			name: "custom word",
+			configure: func(opts *game.Options) {
+				opts.Category, opts.Word = "", "Пёс"
+				withOverrides(game.Override{Key: "selectionStrategy", Value: "bag"})(opts)
+			},
+			steps: []step{{expect: letterPrompt, line: "пёс"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wordsPath := setupData(t)
+
+			out, err := playScript(t, wordsPath, tt.configure, (*game.Game).Run, tt.steps...)
+			assert.NoError(t, err)
+			assert.Contains(t, out, "Шарик улетает в небо!")
+
+			dir, err := os.UserConfigDir()
+			assert.NoError(t, err)
+
+			for _, name := range []string{"stats.json", "seen.json", "bag.json"} {
+				_, err := os.Stat(filepath.Join(dir, "hangman", name))
+				assert.Equal(t, tt.saved, err == nil, name)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package game
+
+import (
+	"io"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/random"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/words"
+)
+
+// DefaultConfigPath - путь к файлу конфига проекта по-умолчанию.
+const DefaultConfigPath = "./internal/infrastructure/files/config.json"
+
+// Override хранит значение параметра конфига, заданное флагом командной строки.
+type Override struct {
+	Key   string
+	Value string
+	Flag  string
+}
+
+// Options хранит параметры запуска игры: путь к файлу конфига проекта, переопределения параметров конфига,
+// источник случайных чисел, заранее выбранные категорию, уровень сложности и тему оформления, фильтр слов,
+// признак исключения уже встречавшихся слов, признак тренировочной игры и слово, заданное игроком.
+// Пустые категория, уровень сложности и тема запрашиваются у пользователя, а фильтр слов заменяет выбор категории.
+// Слово игрока загадывается вместо слова из словаря.
+// Без источника случайных чисел игра использует генератор со случайным зерном.
+// Input и Output задают потоки ввода и вывода игровой консоли, по-умолчанию стандартные.
+type Options struct {
+	ConfigPath string
+	Overrides  []Override
+	Category   string
+	Difficulty string
+	Theme      string
+	Filter     words.Query
+	Unseen     bool
+	Practice   bool
+	Word       string
+	Random     random.Source
+	Input      io.Reader
+	Output     io.Writer
+}
+
+// DefaultOptions возвращает параметры запуска по-умолчанию.
+func DefaultOptions() Options {
+	return Options{
+		ConfigPath: DefaultConfigPath,
+	}
+}
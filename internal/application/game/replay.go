@@ -0,0 +1,209 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/random"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/replay"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/session"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/console"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/loader"
+)
+
+// replayTimeLayout - формат времени начала игры в имени файла записи. Время записывается с микросекундами,
+// чтобы записи партий, начатых в одну секунду, не перезаписывали друг друга.
+const replayTimeLayout = "20060102-150405.000000"
+
+// recorder оборачивает игровую консоль, записывая ввод букв и слов и ответы на предложения исправить раскладку и отменить попытку
+// вместе со временем ввода от начала игры.
+type recorder struct {
+	*console.GameConsole
+	start  time.Time
+	inputs []replay.Input
+}
+
+// newRecorder возвращает указатель на recorder, отсчитывающий время ввода от момента создания.
+func newRecorder(gc *console.GameConsole) *recorder {
+	return &recorder{
+		GameConsole: gc,
+		start:       time.Now(),
+	}
+}
+
+// Enter принимает ввод буквы или слова и записывает его.
+func (r *recorder) Enter() (string, error) {
+	guess, err := r.GameConsole.Enter()
+	if err != nil {
+		return guess, err
+	}
+
+	r.add(replay.InputLetter, guess)
+
+	return guess, nil
+}
+
+// ConfirmLayout предлагает исправить раскладку и записывает ответ пользователя.
+func (r *recorder) ConfirmLayout(typed, converted string) (bool, error) {
+	ok, err := r.GameConsole.ConfirmLayout(typed, converted)
+	if err != nil {
+		return ok, err
+	}
+
+	r.addConfirm(ok)
+
+	return ok, nil
+}
+
+// ConfirmUndo предлагает отменить попытку, завершившую игру, и записывает ответ пользователя.
+func (r *recorder) ConfirmUndo() (bool, error) {
+	ok, err := r.GameConsole.ConfirmUndo()
+	if err != nil {
+		return ok, err
+	}
+
+	r.addConfirm(ok)
+
+	return ok, nil
+}
+
+// addConfirm записывает ответ пользователя на предложение.
+func (r *recorder) addConfirm(ok bool) {
+	answer := "n"
+	if ok {
+		answer = "y"
+	}
+
+	r.add(replay.InputConfirm, answer)
+}
+
+// add записывает строку ввода указанного вида.
+func (r *recorder) add(kind, value string) {
+	r.inputs = append(r.inputs, replay.Input{
+		Ms:    time.Since(r.start).Milliseconds(),
+		Kind:  kind,
+		Value: value,
+	})
+}
+
+// saveReplay сохраняет запись сыгранной игры в пользовательский каталог записей и возвращает путь к ней.
+func (g *Game) saveReplay(rec *recorder) (string, error) {
+	rp := g.session.Recording()
+	rp.Inputs = rec.inputs
+
+	name := fmt.Sprintf("%s-%d.json", rec.start.Format(replayTimeLayout), rp.Seed)
+
+	path, err := dataPath(filepath.Join("replays", name))
+	if err != nil {
+		return "", fmt.Errorf("can`t get replay path: %w", err)
+	}
+
+	err = loader.SaveDataToFile(path, rp)
+	if err != nil {
+		return "", fmt.Errorf("can`t save replay to file: %w", err)
+	}
+
+	if g.config.MaxReplays != 0 {
+		err = pruneReplays(filepath.Dir(path), g.config.MaxReplays)
+		if err != nil {
+			return "", fmt.Errorf("can`t prune replays: %w", err)
+		}
+	}
+
+	return path, nil
+}
+
+// pruneReplays удаляет из каталога dir самые старые записи игр, оставляя keep последних.
+// Имена записей начинаются с даты и времени игры, поэтому порядок имён совпадает с порядком игр.
+func pruneReplays(dir string, keep int) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("can`t read replays dir: %w", err)
+	}
+
+	var names []string
+
+	for _, entry := range entries {
+		if entry.Type().IsRegular() && filepath.Ext(entry.Name()) == ".json" {
+			names = append(names, entry.Name())
+		}
+	}
+
+	for _, name := range names[:max(len(names)-keep, 0)] {
+		err = os.Remove(filepath.Join(dir, name))
+		if err != nil {
+			return fmt.Errorf("can`t remove replay: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Replay воспроизводит запись игры из файла по указанному path, ускоряя ввод и анимации в speed раз.
+// Записи старых версий формата переводятся в текущую. Воспроизведение не учитывается в статистике.
+// Слова, названные целиком, проверяются не по текущему списку слов языка, а по словам, не принятым в записи.
+// Запись прерванной партии и отмена ctx завершают воспроизведение без ошибки.
+func (g *Game) Replay(ctx context.Context, path string, speed float64) error {
+	var raw any
+
+	err := loadFile(path, &raw)
+	if err != nil {
+		return fmt.Errorf("can`t load replay: %w", err)
+	}
+
+	data, err := replay.Migrate(raw)
+	if err != nil {
+		return fmt.Errorf("can`t migrate replay: %w", err)
+	}
+
+	var rp replay.Replay
+
+	err = loader.Decode(data, &rp)
+	if err != nil {
+		return fmt.Errorf("can`t decode replay: %w", err)
+	}
+
+	opts := g.consoleOptions()
+	opts.Practice = rp.Practice
+
+	gc, err := console.NewPlayback(opts, rp.Inputs, speed)
+	if err != nil {
+		return fmt.Errorf("can`t create console: %w", err)
+	}
+
+	g.session = session.New(gc, random.New(rp.Seed))
+	g.session.PresetReplay(rp)
+	g.session.SetKeyboardLayouts(rp.LayoutMode, g.config.KeyboardLayouts)
+
+	gc.SetContext(ctx)
+
+	defer gc.Flush()
+	defer g.session.Events().Close()
+
+	err = g.session.Play(
+		ctx,
+		g.words,
+		g.config.Difficulties,
+		g.config.RandomSelectionCommand,
+		g.config.MsFrameDelay,
+		g.config.MsTransitionDelay,
+		g.themes,
+	)
+	var sigErr *SignalError
+
+	if errors.Is(err, io.EOF) || errors.As(err, &sigErr) {
+		gc.DisplayInterrupted()
+		return nil
+	}
+
+	if err != nil {
+		return displayError(gc, fmt.Errorf("can`t play replay: %w", err))
+	}
+
+	return nil
+}
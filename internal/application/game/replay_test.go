@@ -0,0 +1,81 @@
+package game_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/game"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/random"
+	"github.com/stretchr/testify/assert"
+)
+
+// savedReplays возвращает пути к сохранённым записям игр.
+func savedReplays(t *testing.T) []string {
+	t.Helper()
+
+	dir, err := os.UserConfigDir()
+	assert.NoError(t, err)
+
+	paths, err := filepath.Glob(filepath.Join(dir, "hangman", "replays", "*.json"))
+	assert.NoError(t, err)
+
+	return paths
+}
+
+// withOverrides возвращает функцию, добавляющую в параметры запуска переопределения параметров конфига.
+func withOverrides(overrides ...game.Override) func(opts *game.Options) {
+	return func(opts *game.Options) {
+		opts.Overrides = append(opts.Overrides, overrides...)
+	}
+}
+
+func TestReplayNamesAreUnique(t *testing.T) {
+	wordsPath := setupData(t)
+	// Партии с одним зерном, начатые в одну секунду.
+	configure := func(opts *game.Options) {
+		opts.Random = random.New(1)
+		withOverrides(game.Override{Key: "saveReplays", Value: "true"})(opts)
+	}
+
+	for range 3 {
+		_, err := playScript(t, wordsPath, configure, (*game.Game).Run, step{expect: letterPrompt, line: "кот"})
+		assert.NoError(t, err)
+	}
+
+	assert.Len(t, savedReplays(t), 3)
+}
+
+func TestReplayIgnoresCurrentDictionary(t *testing.T) {
+	wordsPath := setupData(t)
+
+	dictionaryPath := filepath.Join(filepath.Dir(wordsPath), "dictionary.txt")
+	assert.NoError(t, os.WriteFile(dictionaryPath, []byte("кот\n"), 0o600))
+
+	out, err := playScript(t, wordsPath, withOverrides(game.Override{Key: "saveReplays", Value: "true"},
+		game.Override{Key: "dictionaryPath", Value: dictionaryPath}), (*game.Game).Run,
+		step{expect: letterPrompt, line: "кит"},
+		step{expect: letterPrompt, line: "кат"},
+		step{expect: letterPrompt, line: "кот"},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, strings.Count(out, "нет в списке слов"))
+
+	paths := savedReplays(t)
+	assert.Len(t, paths, 1)
+
+	// Список слов изменился: «кит» теперь в нём есть, а при воспроизведении слова по нему не проверяются.
+	assert.NoError(t, os.WriteFile(dictionaryPath, []byte("кит\nкот\n"), 0o600))
+
+	replay := func(g *game.Game, ctx context.Context) error {
+		return g.Replay(ctx, paths[0], 1000)
+	}
+
+	out, err = playScript(t, wordsPath, withOverrides(game.Override{Key: "dictionaryPath", Value: dictionaryPath}), replay)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, strings.Count(out, "нет в списке слов"))
+	assert.NotContains(t, out, "Доступно попыток: 6")
+	assert.Contains(t, out, "Шарик улетает в небо!")
+}
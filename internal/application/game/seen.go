@@ -0,0 +1,73 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/loader"
+)
+
+// loadSeen возвращает множество слов в нижнем регистре, уже встречавшихся пользователю,
+// или пустое множество, если игр ещё не было.
+func loadSeen() (map[string]struct{}, error) {
+	seenPath, err := dataPath("seen.json")
+	if err != nil {
+		return nil, fmt.Errorf("can`t get seen words path: %w", err)
+	}
+
+	var list []string
+
+	err = loadIfExists(seenPath, &list)
+	if err != nil {
+		return nil, fmt.Errorf("can`t load seen words: %w", err)
+	}
+
+	seen := make(map[string]struct{}, len(list))
+	for _, word := range list {
+		seen[word] = struct{}{}
+	}
+
+	return seen, nil
+}
+
+// saveSeen добавляет слово в множество встречавшихся слов и сохраняет его упорядоченным списком.
+func saveSeen(seen map[string]struct{}, word string) error {
+	seen[word] = struct{}{}
+
+	list := make([]string, 0, len(seen))
+	for w := range seen {
+		list = append(list, w)
+	}
+
+	sort.Strings(list)
+
+	seenPath, err := dataPath("seen.json")
+	if err != nil {
+		return fmt.Errorf("can`t get seen words path: %w", err)
+	}
+
+	err = loader.SaveDataToFile(seenPath, list)
+	if err != nil {
+		return fmt.Errorf("can`t save seen words to file: %w", err)
+	}
+
+	return nil
+}
+
+// ResetSeen забывает все встречавшиеся слова, удаляя их файл. Отсутствие файла не считается ошибкой.
+func ResetSeen() error {
+	seenPath, err := dataPath("seen.json")
+	if err != nil {
+		return fmt.Errorf("can`t get seen words path: %w", err)
+	}
+
+	err = os.Remove(seenPath)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("can`t remove seen words: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package game
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// SignalError - партия прервана сигналом операционной системы.
+type SignalError struct {
+	Signal os.Signal
+}
+
+// Error возвращает описание ошибки.
+func (e *SignalError) Error() string {
+	return "interrupted by signal " + e.Signal.String()
+}
+
+// WithSignals возвращает контекст, который отменяется с причиной *SignalError при получении SIGINT или SIGTERM.
+// После первого сигнала обработка снимается, поэтому повторный сигнал завершает программу немедленно.
+// Возвращаемая функция снимает обработку сигналов и отменяет контекст.
+func WithSignals(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancelCause(parent)
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		select {
+		case sig := <-signals:
+			signal.Stop(signals)
+			cancel(&SignalError{Signal: sig})
+		case <-ctx.Done():
+		}
+	}()
+
+	return ctx, func() {
+		signal.Stop(signals)
+		cancel(context.Canceled)
+	}
+}
@@ -0,0 +1,46 @@
+package game
+
+import (
+	"fmt"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/events"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/loader"
+)
+
+// outcome собирает из событий сессии уровень сложности и исход игры для учёта в статистике.
+type outcome struct {
+	difficulty string
+	won        bool
+}
+
+// handle учитывает событие сессии e.
+func (o *outcome) handle(e events.Event) {
+	switch e := e.(type) {
+	case events.ConditionsChosen:
+		o.difficulty = e.Difficulty
+	case events.GameWon:
+		o.won = true
+	}
+}
+
+// saveStats дополняет сохранённую статистику исходом завершённой игры.
+func saveStats(o outcome) error {
+	st, err := LoadStats()
+	if err != nil {
+		return err
+	}
+
+	st.Add(o.difficulty, o.won)
+
+	statsPath, err := dataPath("stats.json")
+	if err != nil {
+		return fmt.Errorf("can`t get stats path: %w", err)
+	}
+
+	err = loader.SaveDataToFile(statsPath, st)
+	if err != nil {
+		return fmt.Errorf("can`t save stats to file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,215 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/frames"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/replay"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/loader"
+)
+
+// suspended хранит прерванную партию: запись игры до прерывания и ключ ежедневного испытания,
+// если прервано испытание.
+type suspended struct {
+	Daily  string        `json:"daily,omitempty"`
+	Replay replay.Replay `json:"replay"`
+}
+
+// resumer оборачивает записывающую консоль и подаёт сессии записанный ввод прерванной партии,
+// ничего не выводя, пока он не закончится. После этого ввод и вывод передаются консоли.
+type resumer struct {
+	*recorder
+	pending []replay.Input
+}
+
+// newResumer возвращает указатель на resumer, продолжающий запись rec с ввода inputs прерванной партии.
+func newResumer(rec *recorder, inputs []replay.Input) *resumer {
+	rec.inputs = slices.Clone(inputs)
+
+	if len(inputs) != 0 {
+		rec.start = time.Now().Add(-time.Duration(inputs[len(inputs)-1].Ms) * time.Millisecond)
+	}
+
+	return &resumer{
+		recorder: rec,
+		pending:  slices.Clone(inputs),
+	}
+}
+
+// Enter возвращает следующую записанную букву или слово или принимает ввод, если записанный ввод закончился.
+func (r *resumer) Enter() (string, error) {
+	value, ok := r.next()
+	if !ok {
+		return r.recorder.Enter()
+	}
+
+	return value, nil
+}
+
+// ConfirmLayout возвращает записанный ответ или предлагает исправить раскладку, если записанный ввод закончился.
+func (r *resumer) ConfirmLayout(typed, converted string) (bool, error) {
+	value, ok := r.next()
+	if !ok {
+		return r.recorder.ConfirmLayout(typed, converted)
+	}
+
+	return value == "y", nil
+}
+
+// ConfirmUndo возвращает записанный ответ или предлагает отменить попытку, если записанный ввод закончился.
+func (r *resumer) ConfirmUndo() (bool, error) {
+	value, ok := r.next()
+	if !ok {
+		return r.recorder.ConfirmUndo()
+	}
+
+	return value == "y", nil
+}
+
+// DisplayLayoutConverted сообщает о замене символа или слова, если записанный ввод закончился.
+func (r *resumer) DisplayLayoutConverted(typed, converted string) {
+	if len(r.pending) == 0 {
+		r.recorder.DisplayLayoutConverted(typed, converted)
+	}
+}
+
+// DisplayHint выводит подсказку, если записанный ввод закончился.
+func (r *resumer) DisplayHint(hint string, number, total int) {
+	if len(r.pending) == 0 {
+		r.recorder.DisplayHint(hint, number, total)
+	}
+}
+
+// DisplayNothingToUndo сообщает, что отменять нечего, если записанный ввод закончился.
+func (r *resumer) DisplayNothingToUndo() {
+	if len(r.pending) == 0 {
+		r.recorder.DisplayNothingToUndo()
+	}
+}
+
+// DisplayError выводит ошибку, если записанный ввод закончился.
+func (r *resumer) DisplayError(err error) {
+	if len(r.pending) == 0 {
+		r.recorder.DisplayError(err)
+	}
+}
+
+// DisplaySessionStatus выводит статус сессии, если записанный ввод закончился.
+func (r *resumer) DisplaySessionStatus(
+	category, difficulty string,
+	fr frames.Frame,
+	displayedWord []rune,
+	attempts int,
+	lettersUsed map[rune]struct{},
+) {
+	if len(r.pending) == 0 {
+		r.recorder.DisplaySessionStatus(category, difficulty, fr, displayedWord, attempts, lettersUsed)
+	}
+}
+
+// PlayTransition проигрывает переход между кадрами, если записанный ввод закончился.
+func (r *resumer) PlayTransition(ctx context.Context, frs []frames.Frame, msDelay int) {
+	if len(r.pending) == 0 {
+		r.recorder.PlayTransition(ctx, frs, msDelay)
+	}
+}
+
+// next возвращает значение следующего записанного ввода и true или false, если записанный ввод закончился.
+func (r *resumer) next() (string, bool) {
+	if len(r.pending) == 0 {
+		return "", false
+	}
+
+	value := r.pending[0].Value
+	r.pending = r.pending[1:]
+
+	return value, true
+}
+
+// resume предлагает продолжить сохранённую прерванную партию, если она относится к ежедневному испытанию dailyKey
+// или, при пустом dailyKey, к обычной игре того же режима. Сохранённая партия удаляется, как только пользователь
+// ответит, а при конце ввода остаётся. Возвращает запись продолжаемой партии или nil, если продолжать нечего или пользователь отказался.
+func (g *Game) resume(rec *recorder, dailyKey string) (*replay.Replay, error) {
+	path, err := dataPath("session.json")
+	if err != nil {
+		return nil, fmt.Errorf("can`t get suspended game path: %w", err)
+	}
+
+	var raw struct {
+		Daily  string `json:"daily"`
+		Replay any    `json:"replay"`
+	}
+
+	err = loader.LoadDataFromFile(path, &raw)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("can`t load suspended game: %w", err)
+	}
+
+	data, err := replay.Migrate(raw.Replay)
+	if err != nil {
+		return nil, fmt.Errorf("can`t migrate suspended game: %w", err)
+	}
+
+	sp := suspended{Daily: raw.Daily}
+
+	err = loader.Decode(data, &sp.Replay)
+	if err != nil {
+		return nil, fmt.Errorf("can`t decode suspended game: %w", err)
+	}
+
+	if sp.Daily != dailyKey || sp.Replay.Practice != g.options.Practice {
+		return nil, nil
+	}
+
+	ok, err := rec.ConfirmResume(sp.Replay.Category, sp.Replay.Difficulty)
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("can`t confirm resume: %w", err)
+	}
+
+	err = os.Remove(path)
+	if err != nil {
+		return nil, fmt.Errorf("can`t remove suspended game: %w", err)
+	}
+
+	if !ok {
+		return nil, nil
+	}
+
+	return &sp.Replay, nil
+}
+
+// suspend сохраняет прерванную партию с вводом, записанным rec, чтобы её можно было продолжить.
+// dailyKey - ключ ежедневного испытания или пустая строка для обычной игры.
+func (g *Game) suspend(rec *recorder, dailyKey string) error {
+	sp := suspended{Daily: dailyKey, Replay: g.session.Recording()}
+	sp.Replay.Inputs = rec.inputs
+
+	path, err := dataPath("session.json")
+	if err != nil {
+		return fmt.Errorf("can`t get suspended game path: %w", err)
+	}
+
+	err = loader.SaveDataToFile(path, sp)
+	if err != nil {
+		return fmt.Errorf("can`t save suspended game to file: %w", err)
+	}
+
+	rec.DisplaySuspended()
+
+	return nil
+}
@@ -0,0 +1,336 @@
+package game_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/game"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	filesDir      = "../../infrastructure/files"
+	letterPrompt  = "Введите букву или слово целиком"
+	savePrompt    = "Сохранить партию, чтобы продолжить её позже?"
+	resumePrompt  = "Есть прерванная партия"
+	interrupted   = "Игра прервана"
+	suspendedGame = "Партия сохранена"
+)
+
+// step - шаг сценария консоли: дождаться текста expect в выводе, затем отменить контекст игры с причиной cause
+// или, если причины нет, ввести строку line.
+type step struct {
+	expect string
+	line   string
+	cause  error
+}
+
+// script - игровая консоль по сценарию: пишет вывод игры в буфер и подаёт ввод по шагам, дожидаясь перед каждым
+// шагом его текста в выводе. После последнего шага ввод заканчивается, а после отмены контекста ожидает
+// следующего шага, чтобы конец ввода не опередил отмену.
+type script struct {
+	mu       sync.Mutex
+	cond     *sync.Cond
+	out      strings.Builder
+	pos      int
+	steps    []step
+	cancel   context.CancelCauseFunc
+	timedOut bool
+}
+
+// newScript возвращает сценарий из шагов steps, отменяющий контекст функцией cancel.
+// Если ожидаемый текст не появился за пять секунд, ввод заканчивается.
+func newScript(cancel context.CancelCauseFunc, steps ...step) *script {
+	s := &script{steps: steps, cancel: cancel}
+	s.cond = sync.NewCond(&s.mu)
+
+	time.AfterFunc(5*time.Second, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.timedOut = true
+		s.cond.Broadcast()
+	})
+
+	return s
+}
+
+// Write дописывает вывод игры.
+func (s *script) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.out.Write(p)
+	s.cond.Broadcast()
+
+	return len(p), nil
+}
+
+// Read подаёт строку следующего шага, когда в выводе появится его текст.
+func (s *script) Read(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	canceled := false
+
+	for {
+		if len(s.steps) == 0 && !canceled {
+			return 0, io.EOF
+		}
+
+		for !s.timedOut && (len(s.steps) == 0 || !strings.Contains(s.out.String()[s.pos:], s.steps[0].expect)) {
+			s.cond.Wait()
+		}
+
+		if s.timedOut {
+			return 0, io.EOF
+		}
+
+		st := s.steps[0]
+		s.steps = s.steps[1:]
+		s.pos += strings.Index(s.out.String()[s.pos:], st.expect) + len(st.expect)
+
+		if st.cause == nil {
+			return copy(p, st.line+"\n"), nil
+		}
+
+		s.cancel(st.cause)
+		canceled = true
+	}
+}
+
+// Output возвращает весь вывод игры.
+func (s *script) Output() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.out.String()
+}
+
+// setupData создаёт каталог данных пользователя и словарь с единственным словом «кот» и возвращает путь к словарю.
+func setupData(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	entry := []map[string]any{{"word": "кот", "hints": []string{"Мяукает"}}}
+	data, err := json.Marshal(map[string]any{"пища": map[string]any{"лёгкая": entry, "средняя": entry, "трудная": entry}})
+	assert.NoError(t, err)
+
+	path := filepath.Join(dir, "words.json")
+	assert.NoError(t, os.WriteFile(path, data, 0o600))
+
+	return path
+}
+
+// runScript играет обычную партию по шагам steps и возвращает её вывод и ошибку.
+func runScript(t *testing.T, wordsPath string, steps ...step) (string, error) {
+	t.Helper()
+
+	return playScript(t, wordsPath, nil, (*game.Game).Run, steps...)
+}
+
+// playScript запускает игру функцией run с заранее выбранными категорией, уровнем сложности и темой по шагам steps
+// и возвращает её вывод и ошибку. Записи игр не сохраняются, если функция configure, изменяющая параметры запуска,
+// не включит это.
+func playScript(
+	t *testing.T,
+	wordsPath string,
+	configure func(opts *game.Options),
+	run func(*game.Game, context.Context) error,
+	steps ...step,
+) (string, error) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	defer cancel(nil)
+
+	s := newScript(cancel, steps...)
+
+	opts := game.DefaultOptions()
+	opts.ConfigPath = filesDir + "/config.json"
+	opts.Category, opts.Difficulty, opts.Theme = "пища", "лёгкая", "шарик"
+	opts.Input, opts.Output = s, s
+	opts.Overrides = []game.Override{
+		{Key: "wordsPath", Value: wordsPath},
+		{Key: "framesPath", Value: filesDir + "/frames.json"},
+		{Key: "themesPath", Value: filesDir + "/themes"},
+		{Key: "msFrameDelay", Value: "0"},
+		{Key: "msTransitionDelay", Value: "0"},
+		{Key: "color", Value: "never"},
+		{Key: "saveReplays", Value: "false"},
+	}
+
+	if configure != nil {
+		configure(&opts)
+	}
+
+	g, err := game.New(opts)
+	assert.NoError(t, err)
+
+	err = run(&g, ctx)
+
+	return s.Output(), err
+}
+
+// sessionPath возвращает путь к файлу сохранённой прерванной партии.
+func sessionPath(t *testing.T) string {
+	t.Helper()
+
+	dir, err := os.UserConfigDir()
+	assert.NoError(t, err)
+
+	return filepath.Join(dir, "hangman", "session.json")
+}
+
+// savedInputs возвращает значения ввода сохранённой прерванной партии.
+func savedInputs(t *testing.T) []string {
+	t.Helper()
+
+	data, err := os.ReadFile(sessionPath(t))
+	assert.NoError(t, err)
+
+	var sp struct {
+		Replay struct {
+			Inputs []struct {
+				Value string `json:"value"`
+			} `json:"inputs"`
+		} `json:"replay"`
+	}
+
+	assert.NoError(t, json.Unmarshal(data, &sp))
+
+	values := make([]string, 0, len(sp.Replay.Inputs))
+	for _, in := range sp.Replay.Inputs {
+		values = append(values, in.Value)
+	}
+
+	return values
+}
+
+func TestRunInterrupt(t *testing.T) {
+	tests := []struct {
+		name       string
+		steps      []step
+		saved      bool
+		savePrompt bool
+	}{
+		{
+			name:  "end of input",
+			steps: []step{{expect: letterPrompt, line: "к"}, {expect: letterPrompt, line: "ы"}},
+		},
+		{
+			name: "interrupt saved",
+			steps: []step{
+				{expect: letterPrompt, line: "к"},
+				{expect: letterPrompt, line: "ы"},
+				{expect: letterPrompt, cause: &game.SignalError{Signal: os.Interrupt}},
+				{expect: savePrompt, line: "д"},
+			},
+			saved:      true,
+			savePrompt: true,
+		},
+		{
+			name: "interrupt declined",
+			steps: []step{
+				{expect: letterPrompt, line: "к"},
+				{expect: letterPrompt, line: "ы"},
+				{expect: letterPrompt, cause: &game.SignalError{Signal: os.Interrupt}},
+				{expect: savePrompt, line: "н"},
+			},
+			savePrompt: true,
+		},
+		{
+			name: "terminate",
+			steps: []step{
+				{expect: letterPrompt, line: "к"},
+				{expect: letterPrompt, line: "ы"},
+				{expect: letterPrompt, cause: &game.SignalError{Signal: syscall.SIGTERM}},
+				{expect: suspendedGame},
+			},
+			saved: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wordsPath := setupData(t)
+
+			out, err := runScript(t, wordsPath, tt.steps...)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.savePrompt, strings.Contains(out, savePrompt))
+			assert.Equal(t, tt.saved, strings.Contains(out, suspendedGame))
+
+			if !tt.savePrompt {
+				assert.Contains(t, out, interrupted)
+			}
+
+			if tt.saved {
+				assert.Equal(t, []string{"к", "ы"}, savedInputs(t))
+			} else {
+				assert.NoFileExists(t, sessionPath(t))
+			}
+		})
+	}
+}
+
+func TestRunResume(t *testing.T) {
+	wordsPath := setupData(t)
+
+	_, err := runScript(t, wordsPath,
+		step{expect: letterPrompt, line: "к"},
+		step{expect: letterPrompt, line: "ы"},
+		step{expect: letterPrompt, cause: &game.SignalError{Signal: syscall.SIGTERM}},
+		step{expect: suspendedGame},
+	)
+	assert.NoError(t, err)
+	assert.FileExists(t, sessionPath(t))
+
+	out, err := runScript(t, wordsPath,
+		step{expect: resumePrompt, line: ""},
+		step{expect: letterPrompt, line: "о"},
+		step{expect: letterPrompt, line: "т"},
+	)
+	assert.NoError(t, err)
+
+	_, resumed, _ := strings.Cut(out, resumePrompt)
+	assert.Equal(t, 2, strings.Count(resumed, letterPrompt), "recorded inputs must be replayed silently")
+
+	state, _, _ := strings.Cut(resumed, letterPrompt)
+	assert.Contains(t, state, "Использованные буквы: к ы\n")
+	assert.Contains(t, state, "Доступно попыток: 6\n")
+	assert.Contains(t, state, "к__\n")
+	assert.Contains(t, resumed, "Шарик улетает в небо!")
+	assert.NoFileExists(t, sessionPath(t))
+}
+
+func TestRunResumeDeclined(t *testing.T) {
+	wordsPath := setupData(t)
+
+	_, err := runScript(t, wordsPath,
+		step{expect: letterPrompt, line: "к"},
+		step{expect: letterPrompt, cause: &game.SignalError{Signal: syscall.SIGTERM}},
+		step{expect: suspendedGame},
+	)
+	assert.NoError(t, err)
+	assert.FileExists(t, sessionPath(t))
+
+	out, err := runScript(t, wordsPath, step{expect: resumePrompt, line: "н"})
+	assert.NoError(t, err)
+
+	_, fresh, _ := strings.Cut(out, resumePrompt)
+	assert.Contains(t, fresh, "Доступно попыток: 7\n")
+	assert.Contains(t, fresh, interrupted)
+	assert.NoFileExists(t, sessionPath(t))
+}
@@ -0,0 +1,145 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/frames"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/schema"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/theme"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/loader"
+)
+
+// Расширения файлов, среди которых ищутся описание и кадры темы в её каталоге.
+var (
+	themeExts  = []string{".json", ".yaml", ".yml", ".toml"}
+	framesExts = []string{".frames", ".json", ".yaml", ".yml", ".toml"}
+)
+
+// Themes возвращает упорядоченный список названий доступных тем оформления.
+func (g *Game) Themes() []string {
+	return schema.SortedKeys(g.themes)
+}
+
+// loadThemes загружает встроенную тему из файла кадров конфига и темы из подкаталогов каталога тем.
+// Каждый подкаталог содержит описание темы theme.* и кадры frames.*. Отсутствующий каталог тем пропускается.
+func (g *Game) loadThemes(problems *schema.Problems) error {
+	g.themes = make(theme.Themes)
+
+	sfm, err := g.loadFrames(g.config.FramesPath, problems)
+	if err != nil {
+		return err
+	}
+
+	if sfm != nil {
+		g.themes[theme.Default] = theme.Theme{Name: theme.Default, Frames: sfm}
+	}
+
+	entries, err := os.ReadDir(g.config.ThemesPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("can`t read themes directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		dir := filepath.Join(g.config.ThemesPath, entry.Name())
+
+		th, err := g.loadTheme(dir, problems)
+		if err != nil {
+			return fmt.Errorf("can`t load theme from %s: %w", dir, err)
+		}
+
+		if th.Frames == nil {
+			continue
+		}
+
+		if _, ok := g.themes[th.Name]; ok {
+			problems.Add(fileSource(dir), "$.name", "theme %q is defined twice", th.Name)
+			continue
+		}
+
+		g.themes[th.Name] = th
+	}
+
+	return nil
+}
+
+// loadTheme загружает тему из каталога. Если тема содержит нарушения схемы, они добавляются в problems,
+// а у возвращаемой темы нет кадров.
+func (g *Game) loadTheme(dir string, problems *schema.Problems) (theme.Theme, error) {
+	var th theme.Theme
+
+	themePath, ok := loader.FindFile(dir, "theme", themeExts...)
+	if !ok {
+		problems.Add(fileSource(dir), schema.Root, "theme description theme.json is missing")
+		return th, nil
+	}
+
+	framesPath, ok := loader.FindFile(dir, "frames", framesExts...)
+	if !ok {
+		problems.Add(fileSource(dir), schema.Root, "theme frames file frames.frames is missing")
+		return th, nil
+	}
+
+	var raw any
+
+	err := loadFile(themePath, &raw)
+	if err != nil {
+		return th, err
+	}
+
+	themeProblems := theme.Validate(raw, fileSource(themePath))
+	*problems = append(*problems, themeProblems...)
+
+	sfm, err := g.loadFrames(framesPath, problems)
+	if err != nil || sfm == nil || len(themeProblems) != 0 {
+		return th, err
+	}
+
+	err = loader.Decode(raw, &th)
+	if err != nil {
+		return th, fmt.Errorf("can`t decode theme: %w", err)
+	}
+
+	th.Frames = sfm
+
+	return th, nil
+}
+
+// loadFrames загружает и проверяет набор кадров. Если набор содержит нарушения схемы,
+// они добавляются в problems, а возвращаемый набор равен nil.
+func (g *Game) loadFrames(path string, problems *schema.Problems) (frames.StageFramesMap, error) {
+	var raw any
+
+	err := loadFile(path, &raw)
+	if err != nil {
+		return nil, fmt.Errorf("can`t load frames from file: %w", err)
+	}
+
+	framesProblems := frames.Validate(raw, fileSource(path))
+	if len(framesProblems) != 0 {
+		*problems = append(*problems, framesProblems...)
+		return nil, nil
+	}
+
+	var sfm frames.StageFramesMap
+
+	err = loader.Decode(raw, &sfm)
+	if err != nil {
+		return nil, fmt.Errorf("can`t decode frames: %w", err)
+	}
+
+	*problems = append(*problems, g.validateFrames(sfm, fileSource(path))...)
+
+	return sfm, nil
+}
@@ -0,0 +1,48 @@
+package game
+
+import (
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/frames"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/schema"
+)
+
+// validateWords проверяет согласованность словаря с уровнями сложности конфига.
+func (g *Game) validateWords() schema.Problems {
+	var problems schema.Problems
+
+	source := fileSource(g.config.WordsPath)
+
+	for _, difficulty := range schema.SortedKeys(g.config.Difficulties) {
+		for _, category := range g.Categories() {
+			if _, ok := g.words[category][difficulty]; !ok {
+				problems.Add(source, schema.Key(schema.Key(schema.Root, category), difficulty),
+					"difficulty %q from config is missing", difficulty)
+			}
+		}
+	}
+
+	for _, category := range g.Categories() {
+		for _, difficulty := range schema.SortedKeys(g.words[category]) {
+			if _, ok := g.config.Difficulties[difficulty]; !ok {
+				problems.Add(source, schema.Key(schema.Key(schema.Root, category), difficulty),
+					"difficulty %q is not defined in config", difficulty)
+			}
+		}
+	}
+
+	return problems
+}
+
+// validateFrames проверяет согласованность набора кадров с длиной анимаций из конфига.
+// Кадров процесса может быть меньше, чем попыток: при создании раскадровки они повторяются.
+func (g *Game) validateFrames(sfm frames.StageFramesMap, source string) schema.Problems {
+	var problems schema.Problems
+
+	for _, stage := range []string{"victory", "defeat"} {
+		if n := len(sfm[stage]); n != g.config.FramesInAnimation {
+			problems.Add(source, schema.Key(schema.Root, stage),
+				"expected %d frames (framesInAnimation in config), got %d", g.config.FramesInAnimation, n)
+		}
+	}
+
+	return problems
+}
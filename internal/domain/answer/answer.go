@@ -6,12 +6,11 @@ import (
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/words"
 )
 
-// Answer хранит слово с подсказкой к нему, его категорию, уровень сложности и таблицу вида "символ - позиции".
+// Answer хранит слово с подсказкой к нему, его категорию и уровень сложности.
 type Answer struct {
 	words.WordData
 	Category   string
 	Difficulty string
-	table      map[rune][]int
 }
 
 // New создаёт новый Answer с приведённым в нижний регистр словом.
@@ -22,22 +21,5 @@ func New(wd words.WordData, category, difficulty string) Answer {
 		WordData:   wd,
 		Category:   category,
 		Difficulty: difficulty,
-		table:      initTable(wd.Word),
 	}
 }
-
-// GetLetterPositions возвращает номера позиций, на которых встречается указанная буква.
-func (a *Answer) GetLetterPositions(letter rune) []int {
-	return a.table[letter]
-}
-
-// initTable инициализирует таблицу, сопоставляя буквам их позиции в слове.
-func initTable(word string) map[rune][]int {
-	table := make(map[rune][]int)
-
-	for i, r := range []rune(word) {
-		table[r] = append(table[r], i)
-	}
-
-	return table
-}
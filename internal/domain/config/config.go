@@ -1,12 +1,35 @@
 package config
 
-import "github.com/es-debug/backend-academy-2024-go-template/internal/domain/conditions"
+import (
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/conditions"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/layout"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/words"
+)
 
+// ColorModes - режимы цветного вывода: auto включает цвета, только если вывод идёт в терминал и не задана переменная NO_COLOR.
+var ColorModes = []string{"auto", "always", "never"}
+
+// Config хранит настройки игры.
 type Config struct {
 	Difficulties           conditions.Difficulties
 	RandomSelectionCommand string
+	SelectionStrategy      string
 	FramesInAnimation      int
 	MsFrameDelay           int
+	MsTransitionDelay      int
+	ReducedMotion          bool
+	Accessible             bool
+	LayoutMode             string
+	DailySalt              string
+	SaveReplays            bool
+	MaxReplays             int
+	Lang                   string
+	Color                  string
+	WordsPath              string
+	FramesPath             string
+	ThemesPath             string
+	DictionaryPath         string
+	KeyboardLayouts        layout.Layouts
 }
 
 // New возвращает инициализированный Config с предустановленными настройками по-умолчанию.
@@ -18,7 +41,25 @@ func New() Config {
 			"трудная": 3,
 		},
 		RandomSelectionCommand: "",
+		SelectionStrategy:      words.StrategyBag,
 		FramesInAnimation:      4,
 		MsFrameDelay:           1250,
+		MsTransitionDelay:      120,
+		ReducedMotion:          false,
+		Accessible:             false,
+		LayoutMode:             layout.ModeAsk,
+		DailySalt:              "",
+		SaveReplays:            true,
+		MaxReplays:             100,
+		Lang:                   "ru",
+		Color:                  "auto",
+		WordsPath:              "./internal/infrastructure/files/words.json",
+		FramesPath:             "./internal/infrastructure/files/frames.json",
+		ThemesPath:             "./internal/infrastructure/files/themes",
+		DictionaryPath:         "",
+		KeyboardLayouts: layout.Layouts{
+			"qwerty": "`qwertyuiop[]asdfghjkl;'zxcvbnm,.",
+			"йцукен": "ёйцукенгшщзхъфывапролджэячсмитьбю",
+		},
 	}
 }
@@ -0,0 +1,272 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"unicode/utf8"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/layout"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/schema"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/words"
+)
+
+// minAttempts - наименьшее допустимое количество попыток на уровне сложности.
+const minAttempts = 2
+
+// SourceDefault - источник значений, заданных по-умолчанию.
+const SourceDefault = "по-умолчанию"
+
+// field описывает параметр конфига: ключ в файле, имя переменной окружения и указатель на поле Config.
+type field struct {
+	key string
+	env string
+	ptr func(c *Config) any
+}
+
+// fields - параметры конфига в порядке вывода.
+var fields = []field{
+	{key: "difficulties", env: "HANGMAN_DIFFICULTIES", ptr: func(c *Config) any { return &c.Difficulties }},
+	{key: "randomSelectionCommand", env: "HANGMAN_RANDOM_SELECTION_COMMAND", ptr: func(c *Config) any { return &c.RandomSelectionCommand }},
+	{key: "selectionStrategy", env: "HANGMAN_SELECTION_STRATEGY", ptr: func(c *Config) any { return &c.SelectionStrategy }},
+	{key: "framesInAnimation", env: "HANGMAN_FRAMES_IN_ANIMATION", ptr: func(c *Config) any { return &c.FramesInAnimation }},
+	{key: "msFrameDelay", env: "HANGMAN_MS_FRAME_DELAY", ptr: func(c *Config) any { return &c.MsFrameDelay }},
+	{key: "msTransitionDelay", env: "HANGMAN_MS_TRANSITION_DELAY", ptr: func(c *Config) any { return &c.MsTransitionDelay }},
+	{key: "reducedMotion", env: "HANGMAN_REDUCED_MOTION", ptr: func(c *Config) any { return &c.ReducedMotion }},
+	{key: "accessible", env: "HANGMAN_ACCESSIBLE", ptr: func(c *Config) any { return &c.Accessible }},
+	{key: "layoutMode", env: "HANGMAN_LAYOUT_MODE", ptr: func(c *Config) any { return &c.LayoutMode }},
+	{key: "dailySalt", env: "HANGMAN_DAILY_SALT", ptr: func(c *Config) any { return &c.DailySalt }},
+	{key: "saveReplays", env: "HANGMAN_SAVE_REPLAYS", ptr: func(c *Config) any { return &c.SaveReplays }},
+	{key: "maxReplays", env: "HANGMAN_MAX_REPLAYS", ptr: func(c *Config) any { return &c.MaxReplays }},
+	{key: "lang", env: "HANGMAN_LANG", ptr: func(c *Config) any { return &c.Lang }},
+	{key: "color", env: "HANGMAN_COLOR", ptr: func(c *Config) any { return &c.Color }},
+	{key: "wordsPath", env: "HANGMAN_WORDS", ptr: func(c *Config) any { return &c.WordsPath }},
+	{key: "framesPath", env: "HANGMAN_FRAMES", ptr: func(c *Config) any { return &c.FramesPath }},
+	{key: "themesPath", env: "HANGMAN_THEMES", ptr: func(c *Config) any { return &c.ThemesPath }},
+	{key: "dictionaryPath", env: "HANGMAN_DICTIONARY", ptr: func(c *Config) any { return &c.DictionaryPath }},
+	{key: "keyboardLayouts", env: "HANGMAN_KEYBOARD_LAYOUTS", ptr: func(c *Config) any { return &c.KeyboardLayouts }},
+}
+
+// Layered хранит конфиг, собранный из нескольких слоёв, и источник каждого его параметра.
+// Каждый следующий слой переопределяет параметры, заданные предыдущими.
+type Layered struct {
+	Config  Config
+	Sources map[string]string
+}
+
+// NewLayered возвращает Layered, содержащий только настройки по-умолчанию.
+func NewLayered() Layered {
+	l := Layered{
+		Config:  New(),
+		Sources: make(map[string]string, len(fields)),
+	}
+
+	for _, f := range fields {
+		l.Sources[f.key] = SourceDefault
+	}
+
+	return l
+}
+
+// Keys возвращает ключи параметров конфига в порядке вывода.
+func Keys() []string {
+	keys := make([]string, 0, len(fields))
+	for _, f := range fields {
+		keys = append(keys, f.key)
+	}
+
+	return keys
+}
+
+// Value возвращает значение параметра по ключу.
+func (l *Layered) Value(key string) (any, error) {
+	f, err := findField(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return reflect.ValueOf(f.ptr(&l.Config)).Elem().Interface(), nil
+}
+
+// ApplyMap переопределяет параметры, присутствующие в декодированном файле конфига.
+// Неизвестные ключи и значения неверного типа не применяются и возвращаются списком нарушений schema.Problems.
+func (l *Layered) ApplyMap(data map[string]any, source string) error {
+	var problems schema.Problems
+
+	for _, key := range schema.SortedKeys(data) {
+		f, err := findField(key)
+		if err != nil {
+			problems.Add(source, schema.Key(schema.Root, key), "unknown field")
+			continue
+		}
+
+		encoded, err := json.Marshal(data[key])
+		if err != nil {
+			problems.Add(source, schema.Key(schema.Root, key), "can`t encode value: %v", err)
+			continue
+		}
+
+		err = l.assign(f, encoded, source)
+		if err != nil {
+			problems.Add(source, schema.Key(schema.Root, key), "%v", err)
+		}
+	}
+
+	return problems.Err()
+}
+
+// Validate проверяет допустимость итоговых значений параметров и возвращает все найденные нарушения,
+// указывая в качестве источника слой, из которого было взято значение.
+func (l *Layered) Validate() schema.Problems {
+	var problems schema.Problems
+
+	c := l.Config
+
+	if len(c.Difficulties) == 0 {
+		problems.Add(l.Sources["difficulties"], "$.difficulties", "at least one difficulty is required")
+	}
+
+	for _, difficulty := range schema.SortedKeys(c.Difficulties) {
+		if c.Difficulties[difficulty] < minAttempts {
+			problems.Add(l.Sources["difficulties"], schema.Key("$.difficulties", difficulty),
+				"attempts must be at least %d, got %d", minAttempts, c.Difficulties[difficulty])
+		}
+	}
+
+	if c.FramesInAnimation < 1 {
+		problems.Add(l.Sources["framesInAnimation"], "$.framesInAnimation", "must be at least 1, got %d", c.FramesInAnimation)
+	}
+
+	if c.MsFrameDelay < 0 {
+		problems.Add(l.Sources["msFrameDelay"], "$.msFrameDelay", "must not be negative, got %d", c.MsFrameDelay)
+	}
+
+	if c.MsTransitionDelay < 0 {
+		problems.Add(l.Sources["msTransitionDelay"], "$.msTransitionDelay", "must not be negative, got %d", c.MsTransitionDelay)
+	}
+
+	if c.MaxReplays < 0 {
+		problems.Add(l.Sources["maxReplays"], "$.maxReplays", "must not be negative, got %d", c.MaxReplays)
+	}
+
+	if !slices.Contains(words.Strategies, c.SelectionStrategy) {
+		problems.Add(l.Sources["selectionStrategy"], "$.selectionStrategy",
+			"unknown selection strategy %q, expected one of %v", c.SelectionStrategy, words.Strategies)
+	}
+
+	if !slices.Contains(ColorModes, c.Color) {
+		problems.Add(l.Sources["color"], "$.color", "unknown color mode %q, expected one of %v", c.Color, ColorModes)
+	}
+
+	if !slices.Contains(layout.Modes, c.LayoutMode) {
+		problems.Add(l.Sources["layoutMode"], "$.layoutMode", "unknown layout mode %q, expected one of %v", c.LayoutMode, layout.Modes)
+	}
+
+	keys := -1
+
+	for _, name := range schema.SortedKeys(c.KeyboardLayouts) {
+		count := utf8.RuneCountInString(c.KeyboardLayouts[name])
+
+		if keys == -1 {
+			keys = count
+		} else if count != keys {
+			problems.Add(l.Sources["keyboardLayouts"], schema.Key("$.keyboardLayouts", name),
+				"must have the same number of keys as other layouts (%d), got %d", keys, count)
+		}
+	}
+
+	for _, key := range []string{"lang", "wordsPath", "framesPath"} {
+		if value, _ := l.Value(key); value == "" {
+			problems.Add(l.Sources[key], schema.Key(schema.Root, key), "must not be empty")
+		}
+	}
+
+	return problems
+}
+
+// ApplyEnv переопределяет параметры, для которых заданы переменные окружения HANGMAN_*.
+// lookup возвращает значение переменной окружения и признак её наличия, как os.LookupEnv.
+// Значения неверного типа не применяются и возвращаются списком нарушений schema.Problems.
+func (l *Layered) ApplyEnv(lookup func(string) (string, bool)) error {
+	var problems schema.Problems
+
+	for _, f := range fields {
+		value, ok := lookup(f.env)
+		if !ok {
+			continue
+		}
+
+		source := "переменная окружения " + f.env
+
+		err := l.set(f, value, source)
+		if err != nil {
+			problems.Add(source, schema.Key(schema.Root, f.key), "%v", err)
+		}
+	}
+
+	return problems.Err()
+}
+
+// Set переопределяет параметр по ключу значением, записанным в виде строки.
+// Строковые параметры присваиваются как есть, остальные декодируются из JSON.
+// Неизвестный ключ и значение неверного типа возвращаются списком нарушений schema.Problems.
+func (l *Layered) Set(key, value, source string) error {
+	var problems schema.Problems
+
+	f, err := findField(key)
+	if err != nil {
+		problems.Add(source, schema.Key(schema.Root, key), "unknown field")
+		return problems
+	}
+
+	err = l.set(f, value, source)
+	if err != nil {
+		problems.Add(source, schema.Key(schema.Root, key), "%v", err)
+		return problems
+	}
+
+	return nil
+}
+
+// set присваивает параметру значение, записанное в виде строки.
+func (l *Layered) set(f field, value, source string) error {
+	if p, ok := f.ptr(&l.Config).(*string); ok {
+		*p = value
+		l.Sources[f.key] = source
+
+		return nil
+	}
+
+	return l.assign(f, []byte(value), source)
+}
+
+// assign декодирует значение параметра из JSON, полностью заменяя предыдущее значение.
+func (l *Layered) assign(f field, encoded []byte, source string) error {
+	target := reflect.New(reflect.TypeOf(f.ptr(&l.Config)).Elem())
+
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.DisallowUnknownFields()
+
+	err := decoder.Decode(target.Interface())
+	if err != nil {
+		return fmt.Errorf("invalid value: %w", err)
+	}
+
+	reflect.ValueOf(f.ptr(&l.Config)).Elem().Set(target.Elem())
+	l.Sources[f.key] = source
+
+	return nil
+}
+
+// findField возвращает описание параметра по ключу.
+func findField(key string) (field, error) {
+	for _, f := range fields {
+		if f.key == key {
+			return f, nil
+		}
+	}
+
+	return field{}, fmt.Errorf("unknown config key %q", key)
+}
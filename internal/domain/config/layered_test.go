@@ -0,0 +1,175 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/conditions"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLayered(t *testing.T) {
+	l := config.NewLayered()
+
+	err := l.ApplyMap(map[string]any{
+		"difficulties": map[string]any{"лёгкая": 6},
+		"msFrameDelay": 100,
+	}, "файл")
+	assert.NoError(t, err)
+
+	env := map[string]string{
+		"HANGMAN_MS_FRAME_DELAY": "200",
+		"HANGMAN_LANG":           "en",
+	}
+
+	err = l.ApplyEnv(func(key string) (string, bool) {
+		value, ok := env[key]
+		return value, ok
+	})
+	assert.NoError(t, err)
+
+	err = l.Set("lang", "ru", "флаг")
+	assert.NoError(t, err)
+
+	assert.Equal(t, conditions.Difficulties{"лёгкая": 6}, l.Config.Difficulties)
+	assert.Equal(t, 200, l.Config.MsFrameDelay)
+	assert.Equal(t, "ru", l.Config.Lang)
+	assert.Equal(t, 4, l.Config.FramesInAnimation)
+
+	assert.Equal(t, "файл", l.Sources["difficulties"])
+	assert.Equal(t, "переменная окружения HANGMAN_MS_FRAME_DELAY", l.Sources["msFrameDelay"])
+	assert.Equal(t, "флаг", l.Sources["lang"])
+	assert.Equal(t, config.SourceDefault, l.Sources["framesInAnimation"])
+
+	assert.Error(t, l.Set("msFrameDelay", "abc", "флаг"))
+	assert.Error(t, l.Set("unknownKey", "1", "флаг"))
+}
+
+func TestLayeredProblems(t *testing.T) {
+	l := config.NewLayered()
+
+	err := l.ApplyMap(map[string]any{
+		"MsFrameDelay":      100,
+		"framesInAnimation": "четыре",
+		"difficulties":      map[string]any{"лёгкая": 1},
+	}, "config.json")
+
+	var problems schema.Problems
+
+	assert.ErrorAs(t, err, &problems)
+	assert.Len(t, problems, 2)
+	assert.Equal(t, "$.MsFrameDelay", problems[0].Path)
+	assert.Equal(t, "unknown field", problems[0].Message)
+	assert.Equal(t, "$.framesInAnimation", problems[1].Path)
+
+	problems = l.Validate()
+
+	assert.Len(t, problems, 1)
+	assert.Equal(t, "config.json", problems[0].Source)
+	assert.Equal(t, "$.difficulties.лёгкая", problems[0].Path)
+}
+
+func TestLayeredValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     map[string]any
+		expected schema.Problems
+	}{
+		{
+			name: "defaults",
+			data: map[string]any{},
+		},
+		{
+			name: "no difficulties",
+			data: map[string]any{"difficulties": map[string]any{}},
+			expected: schema.Problems{
+				{Source: "config.json", Path: "$.difficulties", Message: "at least one difficulty is required"},
+			},
+		},
+		{
+			name: "too few attempts",
+			data: map[string]any{"difficulties": map[string]any{"лёгкая": 7, "средняя": 0, "трудная": 1}},
+			expected: schema.Problems{
+				{Source: "config.json", Path: "$.difficulties.средняя", Message: "attempts must be at least 2, got 0"},
+				{Source: "config.json", Path: "$.difficulties.трудная", Message: "attempts must be at least 2, got 1"},
+			},
+		},
+		{
+			name: "out of range numbers",
+			data: map[string]any{"framesInAnimation": 0, "msFrameDelay": -1, "msTransitionDelay": -2, "maxReplays": -3},
+			expected: schema.Problems{
+				{Source: "config.json", Path: "$.framesInAnimation", Message: "must be at least 1, got 0"},
+				{Source: "config.json", Path: "$.msFrameDelay", Message: "must not be negative, got -1"},
+				{Source: "config.json", Path: "$.msTransitionDelay", Message: "must not be negative, got -2"},
+				{Source: "config.json", Path: "$.maxReplays", Message: "must not be negative, got -3"},
+			},
+		},
+		{
+			name: "unknown modes",
+			data: map[string]any{"selectionStrategy": "first", "color": "rainbow", "layoutMode": "guess"},
+			expected: schema.Problems{
+				{
+					Source:  "config.json",
+					Path:    "$.selectionStrategy",
+					Message: `unknown selection strategy "first", expected one of [uniform weighted bag]`,
+				},
+				{Source: "config.json", Path: "$.color", Message: `unknown color mode "rainbow", expected one of [auto always never]`},
+				{Source: "config.json", Path: "$.layoutMode", Message: `unknown layout mode "guess", expected one of [ask convert off]`},
+			},
+		},
+		{
+			name: "keyboard layouts of different sizes",
+			data: map[string]any{"keyboardLayouts": map[string]any{"abc": "abc", "абв": "абв", "абвг": "абвг"}},
+			expected: schema.Problems{
+				{Source: "config.json", Path: "$.keyboardLayouts.абвг", Message: "must have the same number of keys as other layouts (3), got 4"},
+			},
+		},
+		{
+			name: "empty values",
+			data: map[string]any{"lang": "", "wordsPath": "", "framesPath": ""},
+			expected: schema.Problems{
+				{Source: "config.json", Path: "$.lang", Message: "must not be empty"},
+				{Source: "config.json", Path: "$.wordsPath", Message: "must not be empty"},
+				{Source: "config.json", Path: "$.framesPath", Message: "must not be empty"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := config.NewLayered()
+
+			assert.NoError(t, l.ApplyMap(tt.data, "config.json"))
+			assert.Equal(t, tt.expected, l.Validate())
+		})
+	}
+}
+
+func TestLayeredValidateSources(t *testing.T) {
+	l := config.NewLayered()
+
+	assert.NoError(t, l.ApplyMap(map[string]any{"msFrameDelay": -1, "maxReplays": -1}, "config.json"))
+	assert.NoError(t, l.Set("maxReplays", "-5", "флаг -max-replays"))
+
+	assert.Equal(t, schema.Problems{
+		{Source: "config.json", Path: "$.msFrameDelay", Message: "must not be negative, got -1"},
+		{Source: "флаг -max-replays", Path: "$.maxReplays", Message: "must not be negative, got -5"},
+	}, l.Validate())
+}
+
+func TestLayeredUnknownKeys(t *testing.T) {
+	l := config.NewLayered()
+
+	err := l.ApplyMap(map[string]any{"lang": "en", "colour": "never", "Lang": "ru", "wordPath": "words.json"}, "config.yaml")
+
+	var problems schema.Problems
+
+	assert.ErrorAs(t, err, &problems)
+	assert.Equal(t, schema.Problems{
+		{Source: "config.yaml", Path: "$.Lang", Message: "unknown field"},
+		{Source: "config.yaml", Path: "$.colour", Message: "unknown field"},
+		{Source: "config.yaml", Path: "$.wordPath", Message: "unknown field"},
+	}, problems)
+	assert.Equal(t, "en", l.Config.Lang)
+}
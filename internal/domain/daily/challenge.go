@@ -0,0 +1,67 @@
+package daily
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+)
+
+const (
+	dateLayout  = "2006-01-02"
+	summaryForm = "Виселица, испытание дня %s: %s/%d"
+	hitMark     = "🟩"
+	missMark    = "🟥"
+	defeatMark  = "X"
+)
+
+// Challenge хранит дату ежедневного испытания и выведенное из неё зерно генератора случайных чисел.
+type Challenge struct {
+	Date time.Time
+	Seed uint64
+}
+
+// New возвращает испытание на указанную дату, зерно которого зависит только от даты и соли.
+// Дата берётся в UTC, поэтому в один и тот же момент у игроков во всех часовых поясах одно испытание.
+func New(date time.Time, salt string) Challenge {
+	date = date.UTC()
+
+	h := fnv.New64a()
+	h.Write([]byte(date.Format(dateLayout) + ":" + salt))
+
+	return Challenge{
+		Date: date,
+		Seed: h.Sum64(),
+	}
+}
+
+// Key возвращает ключ испытания, по которому оно отмечается в журнале сыгранных дней.
+func (c Challenge) Key() string {
+	return c.Date.Format(dateLayout)
+}
+
+// Summary возвращает результат испытания в виде строки из эмодзи, которой можно поделиться.
+// guesses - последовательность попаданий (true) и промахов (false) в порядке ввода букв.
+func (c Challenge) Summary(guesses []bool, won bool, maxAttempts int) string {
+	var (
+		mistakes int
+		marks    strings.Builder
+	)
+
+	for _, hit := range guesses {
+		if hit {
+			marks.WriteString(hitMark)
+		} else {
+			marks.WriteString(missMark)
+
+			mistakes++
+		}
+	}
+
+	score := defeatMark
+	if won {
+		score = fmt.Sprint(mistakes)
+	}
+
+	return fmt.Sprintf(summaryForm, c.Key(), score, maxAttempts) + "\n" + marks.String()
+}
@@ -0,0 +1,57 @@
+package daily_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/daily"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	date := time.Date(2024, time.October, 1, 9, 0, 0, 0, time.UTC)
+	sameDay := time.Date(2024, time.October, 1, 23, 0, 0, 0, time.UTC)
+	nextDay := time.Date(2024, time.October, 2, 9, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, daily.New(date, "соль").Seed, daily.New(sameDay, "соль").Seed)
+	assert.NotEqual(t, daily.New(date, "соль").Seed, daily.New(nextDay, "соль").Seed)
+	assert.NotEqual(t, daily.New(date, "соль").Seed, daily.New(date, "перец").Seed)
+}
+
+func TestSummary(t *testing.T) {
+	tt := []struct {
+		guesses  []bool
+		won      bool
+		expected string
+	}{
+		{
+			guesses:  []bool{true, false, true},
+			won:      true,
+			expected: "Виселица, испытание дня 2024-10-01: 1/5\n🟩🟥🟩",
+		},
+		{
+			guesses:  []bool{false, false, false, false, false},
+			won:      false,
+			expected: "Виселица, испытание дня 2024-10-01: X/5\n🟥🟥🟥🟥🟥",
+		},
+	}
+
+	c := daily.New(time.Date(2024, time.October, 1, 0, 0, 0, 0, time.UTC), "")
+
+	for _, tc := range tt {
+		assert.Equal(t, tc.expected, c.Summary(tc.guesses, tc.won, 5))
+	}
+}
+
+func TestNewTimezones(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+	losAngeles := time.FixedZone("PDT", -7*60*60)
+
+	// 2024-10-01 01:30 MSK и 2024-09-30 16:30 PDT - разные местные даты одного дня UTC 2024-09-30.
+	first := daily.New(time.Date(2024, time.October, 1, 1, 30, 0, 0, moscow), "соль")
+	second := daily.New(time.Date(2024, time.September, 30, 16, 30, 0, 0, losAngeles), "соль")
+
+	assert.Equal(t, first.Seed, second.Seed)
+	assert.Equal(t, "2024-09-30", first.Key())
+	assert.Equal(t, first.Key(), second.Key())
+}
@@ -0,0 +1,15 @@
+package daily
+
+// Record - журнал сыгранных испытаний, сопоставляющий ключу испытания его итоговый результат.
+type Record map[string]string
+
+// IsPlayed возвращает true, если испытание уже было сыграно, иначе false.
+func (r Record) IsPlayed(c Challenge) bool {
+	_, ok := r[c.Key()]
+	return ok
+}
+
+// Add отмечает испытание сыгранным, сохраняя его результат.
+func (r Record) Add(c Challenge, summary string) {
+	r[c.Key()] = summary
+}
@@ -0,0 +1,237 @@
+package dictionary
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/words"
+)
+
+// Wildcard - символ шаблона, обозначающий любую букву.
+const Wildcard = '_'
+
+var (
+	// ErrInvalidPattern - шаблон пуст или содержит символы, отличные от букв и Wildcard.
+	ErrInvalidPattern = errors.New("invalid pattern")
+	// ErrUnknownWord - слова нет в словаре.
+	ErrUnknownWord = errors.New("word is not in the dictionary")
+)
+
+// WordError - ошибка слова, не прошедшего проверку по словарю. Err - ErrUnknownWord.
+type WordError struct {
+	Word string
+	Err  error
+}
+
+// Error возвращает описание ошибки.
+func (e *WordError) Error() string {
+	return fmt.Sprintf("word %q: %v", e.Word, e.Err)
+}
+
+// Unwrap возвращает причину ошибки.
+func (e *WordError) Unwrap() error {
+	return e.Err
+}
+
+// Dictionary хранит слова в нижнем регистре упорядоченными множествами, сгруппированными по длине слова.
+// Поиск слова и слов по префиксу или шаблону выполняется двоичным поиском в множестве слов нужной длины.
+type Dictionary struct {
+	byLength map[int][]string
+	size     int
+}
+
+// New возвращает указатель на Dictionary, содержащий слова list без повторов и без учёта регистра.
+// Слова проверяются так же, как слова словаря игры функцией words.CheckWord: строки без букв пропускаются,
+// а слова с другими символами, например «кто-то», сохраняются.
+func New(list []string) *Dictionary {
+	d := &Dictionary{byLength: make(map[int][]string)}
+
+	for _, word := range list {
+		word = strings.ToLower(strings.TrimSpace(word))
+		if words.CheckWord(word) != nil {
+			continue
+		}
+
+		length := utf8.RuneCountInString(word)
+		d.byLength[length] = append(d.byLength[length], word)
+	}
+
+	for length, sorted := range d.byLength {
+		sort.Strings(sorted)
+
+		d.byLength[length] = slices.Compact(sorted)
+		d.size += len(d.byLength[length])
+	}
+
+	return d
+}
+
+// Len возвращает количество слов словаря.
+func (d *Dictionary) Len() int {
+	return d.size
+}
+
+// Contains возвращает true, если слово есть в словаре без учёта регистра, иначе false.
+func (d *Dictionary) Contains(word string) bool {
+	word = strings.ToLower(word)
+
+	_, found := slices.BinarySearch(d.byLength[utf8.RuneCountInString(word)], word)
+
+	return found
+}
+
+// Prefix возвращает упорядоченные слова словаря, начинающиеся с prefix без учёта регистра.
+func (d *Dictionary) Prefix(prefix string) []string {
+	prefix = strings.ToLower(prefix)
+
+	var result []string
+
+	for length, sorted := range d.byLength {
+		if length >= utf8.RuneCountInString(prefix) {
+			result = append(result, withPrefix(sorted, prefix)...)
+		}
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+// Match возвращает упорядоченные слова словаря, подходящие под шаблон без учёта регистра:
+// шаблон задаёт длину слова и буквы на известных местах, а Wildcard обозначает любую букву, например "к_т__".
+func (d *Dictionary) Match(pattern string) ([]string, error) {
+	pattern, err := parsePattern(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.match(pattern, func(string) bool { return true }), nil
+}
+
+// Candidates возвращает слова, которые могут быть загаданы в игре с открытыми буквами шаблона pattern
+// и неверными догадками wrong: на местах Wildcard не может стоять ни открытая буква, ни неверная догадка,
+// так как открытая буква открывается сразу на всех своих местах.
+func (d *Dictionary) Candidates(pattern string, wrong []rune) ([]string, error) {
+	pattern, err := parsePattern(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	excluded := make(map[rune]struct{})
+
+	for _, r := range pattern {
+		if r != Wildcard {
+			excluded[r] = struct{}{}
+		}
+	}
+
+	for _, r := range wrong {
+		excluded[unicode.ToLower(r)] = struct{}{}
+	}
+
+	patternRunes := []rune(pattern)
+
+	return d.match(pattern, func(word string) bool {
+		for i, r := range []rune(word) {
+			if _, ok := excluded[r]; ok && patternRunes[i] == Wildcard {
+				return false
+			}
+		}
+
+		return true
+	}), nil
+}
+
+// Suggest возвращает букву, которая встречается в наибольшем количестве слов candidates и ещё не была названа,
+// и true или 0 и false, если таких букв нет. Из букв с одинаковым количеством слов выбирается меньшая.
+func Suggest(candidates []string, tried []rune) (rune, bool) {
+	counts := make(map[rune]int)
+
+	for _, word := range candidates {
+		letters := make(map[rune]struct{})
+		for _, r := range word {
+			letters[r] = struct{}{}
+		}
+
+		for r := range letters {
+			if !slices.Contains(tried, r) {
+				counts[r]++
+			}
+		}
+	}
+
+	var (
+		best  rune
+		found bool
+	)
+
+	for r, count := range counts {
+		if !found || count > counts[best] || (count == counts[best] && r < best) {
+			best, found = r, true
+		}
+	}
+
+	return best, found
+}
+
+// match возвращает упорядоченные слова длины шаблона, подходящие под шаблон и принятые keep.
+// Слова ищутся в диапазоне, начинающемся с букв шаблона до первого Wildcard.
+func (d *Dictionary) match(pattern string, keep func(word string) bool) []string {
+	patternRunes := []rune(pattern)
+	prefix, _, _ := strings.Cut(pattern, string(Wildcard))
+
+	var result []string
+
+	for _, word := range withPrefix(d.byLength[len(patternRunes)], prefix) {
+		if matches(patternRunes, word) && keep(word) {
+			result = append(result, word)
+		}
+	}
+
+	return result
+}
+
+// matches возвращает true, если буквы слова совпадают с буквами шаблона на всех местах, отличных от Wildcard,
+// а на местах Wildcard стоят буквы, иначе false.
+func matches(pattern []rune, word string) bool {
+	for i, r := range []rune(word) {
+		if (pattern[i] == Wildcard && !unicode.IsLetter(r)) || (pattern[i] != Wildcard && pattern[i] != r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// withPrefix возвращает подслайс упорядоченного списка слов, начинающихся с prefix.
+func withPrefix(list []string, prefix string) []string {
+	start, _ := slices.BinarySearch(list, prefix)
+
+	end := start
+	for end < len(list) && strings.HasPrefix(list[end], prefix) {
+		end++
+	}
+
+	return list[start:end]
+}
+
+// parsePattern возвращает шаблон в нижнем регистре или ErrInvalidPattern, если шаблон пуст
+// или содержит символы, отличные от букв и Wildcard.
+func parsePattern(pattern string) (string, error) {
+	if pattern == "" {
+		return "", fmt.Errorf("%w: pattern must not be empty", ErrInvalidPattern)
+	}
+
+	for _, r := range pattern {
+		if r != Wildcard && !unicode.IsLetter(r) {
+			return "", fmt.Errorf("%w %q: must contain only letters and %q, got %q", ErrInvalidPattern, pattern, Wildcard, r)
+		}
+	}
+
+	return strings.ToLower(pattern), nil
+}
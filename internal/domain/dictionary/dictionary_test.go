@@ -0,0 +1,63 @@
+package dictionary_test
+
+import (
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/dictionary"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDictionary(t *testing.T) {
+	d := dictionary.New([]string{"кот", "Кит", "коты", "катер", "комар", "корова", "кто-то", "КОТ", " кулак ", "1984"})
+
+	assert.Equal(t, 8, d.Len())
+	assert.True(t, d.Contains("Кот"))
+	assert.True(t, d.Contains("кулак"))
+	assert.True(t, d.Contains("Кто-то"))
+	assert.False(t, d.Contains("кто"))
+
+	assert.Equal(t, []string{"комар", "корова", "кот", "коты"}, d.Prefix("Ко"))
+	assert.Empty(t, d.Prefix("я"))
+
+	found, err := d.Match("К_т")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"кит", "кот"}, found)
+
+	found, err = d.Match("_о___")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"комар"}, found)
+
+	found, err = d.Match("______")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"корова"}, found)
+
+	_, err = d.Match("к-т")
+	assert.ErrorIs(t, err, dictionary.ErrInvalidPattern)
+
+	_, err = d.Match("")
+	assert.ErrorIs(t, err, dictionary.ErrInvalidPattern)
+}
+
+func TestCandidates(t *testing.T) {
+	d := dictionary.New([]string{"катер", "комар", "кулак", "карта", "кабак"})
+
+	found, err := d.Candidates("к___р", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"катер", "комар"}, found)
+
+	found, err = d.Candidates("к___р", []rune("О"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"катер"}, found)
+
+	// Открытые буквы открываются на всех своих местах, поэтому кабак и карта не подходят.
+	found, err = d.Candidates("ка___", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"катер"}, found)
+
+	letter, ok := dictionary.Suggest([]string{"катер", "комар"}, []rune("кр"))
+	assert.True(t, ok)
+	assert.Equal(t, 'а', letter)
+
+	_, ok = dictionary.Suggest([]string{"кот"}, []rune("кот"))
+	assert.False(t, ok)
+}
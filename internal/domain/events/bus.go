@@ -0,0 +1,125 @@
+package events
+
+import (
+	"errors"
+	"slices"
+	"sync"
+)
+
+// ErrClosed - шина событий закрыта и не принимает подписчиков.
+var ErrClosed = errors.New("event bus is closed")
+
+// Handler обрабатывает опубликованное событие.
+type Handler func(e Event)
+
+// Bus рассылает события подписчикам в порядке подписки.
+// Синхронные подписчики вызываются в горутине издателя, асинхронные получают события через буферизованный канал
+// и обрабатывают их в собственной горутине, не задерживая издателя, пока буфер не заполнен.
+// Обработчики вызываются без блокировки шины, поэтому синхронные обработчики могут публиковать события
+// и подписываться, но никакие обработчики не должны закрывать шину.
+type Bus struct {
+	mu         sync.Mutex
+	subs       []Handler
+	queues     []chan Event
+	publishing sync.WaitGroup
+	wg         sync.WaitGroup
+	closed     bool
+}
+
+// NewBus возвращает указатель на шину событий без подписчиков.
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+// Subscribe подписывает синхронный обработчик h на все события шины.
+// После Close возвращает ErrClosed.
+func (b *Bus) Subscribe(h Handler) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return ErrClosed
+	}
+
+	b.subs = append(b.subs, h)
+
+	return nil
+}
+
+// SubscribeAsync подписывает обработчик h, вызываемый в отдельной горутине, на все события шины.
+// События накапливаются в буфере размером buffer; при заполненном буфере Publish ожидает обработчик.
+// После Close возвращает ErrClosed.
+func (b *Bus) SubscribeAsync(h Handler, buffer int) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return ErrClosed
+	}
+
+	queue := make(chan Event, max(buffer, 0))
+
+	b.queues = append(b.queues, queue)
+	b.subs = append(b.subs, func(e Event) {
+		queue <- e
+	})
+
+	b.wg.Add(1)
+
+	go func() {
+		defer b.wg.Done()
+
+		for e := range queue {
+			h(e)
+		}
+	}()
+
+	return nil
+}
+
+// Publish публикует событие e всем подписчикам, подписанным к моменту публикации. После Close события не публикуются.
+func (b *Bus) Publish(e Event) {
+	b.mu.Lock()
+
+	if b.closed {
+		b.mu.Unlock()
+		return
+	}
+
+	subs := slices.Clone(b.subs)
+
+	b.publishing.Add(1)
+	defer b.publishing.Done()
+
+	b.mu.Unlock()
+
+	for _, h := range subs {
+		h(e)
+	}
+}
+
+// Close закрывает шину, ожидает завершения начатых публикаций и ожидает,
+// пока асинхронные подписчики обработают накопленные события.
+func (b *Bus) Close() {
+	b.mu.Lock()
+
+	if b.closed {
+		b.mu.Unlock()
+		b.wg.Wait()
+
+		return
+	}
+
+	b.closed = true
+	queues := b.queues
+
+	b.mu.Unlock()
+
+	b.publishing.Wait()
+
+	for _, queue := range queues {
+		close(queue)
+	}
+
+	b.wg.Wait()
+}
@@ -0,0 +1,125 @@
+package events_test
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/events"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBus(t *testing.T) {
+	bus := events.NewBus()
+
+	var sync, async []string
+
+	err := bus.Subscribe(func(e events.Event) {
+		sync = append(sync, e.Name())
+	})
+	assert.NoError(t, err)
+
+	err = bus.SubscribeAsync(func(e events.Event) {
+		async = append(async, e.Name())
+	}, 1)
+	assert.NoError(t, err)
+
+	published := []events.Event{
+		events.GameStarted{Seed: 7},
+		events.LetterGuessed{Letter: 'а', Hit: true, Positions: []int{0}},
+		events.AttemptLost{AttemptsLeft: 2},
+		events.HintUsed{Hint: "ягода", Number: 1},
+		events.GuessUndone{AttemptsLeft: 3},
+		events.GameLost{Word: "вишня"},
+	}
+
+	for _, e := range published {
+		bus.Publish(e)
+	}
+
+	expected := []string{"GameStarted", "LetterGuessed", "AttemptLost", "HintUsed", "GuessUndone", "GameLost"}
+
+	assert.Equal(t, expected, sync)
+
+	bus.Close()
+	bus.Publish(events.HintUsed{})
+
+	assert.Equal(t, expected, async)
+	assert.Equal(t, expected, sync)
+}
+
+func TestBusClosed(t *testing.T) {
+	bus := events.NewBus()
+	bus.Close()
+
+	assert.ErrorIs(t, bus.Subscribe(func(events.Event) {}), events.ErrClosed)
+	assert.ErrorIs(t, bus.SubscribeAsync(func(events.Event) {}, 1), events.ErrClosed)
+
+	bus.Close()
+}
+
+func TestBusConcurrent(t *testing.T) {
+	bus := events.NewBus()
+
+	var sync, async atomic.Int64
+
+	err := bus.Subscribe(func(e events.Event) {
+		sync.Add(1)
+
+		if _, ok := e.(events.GameLost); ok {
+			bus.Publish(events.GameStarted{})
+		}
+	})
+	assert.NoError(t, err)
+
+	err = bus.SubscribeAsync(func(events.Event) {
+		async.Add(1)
+	}, 0)
+	assert.NoError(t, err)
+
+	done := make(chan struct{})
+
+	for range 8 {
+		go func() {
+			defer func() { done <- struct{}{} }()
+
+			for range 100 {
+				bus.Publish(events.GameLost{})
+			}
+		}()
+	}
+
+	for range 8 {
+		<-done
+	}
+
+	bus.Close()
+
+	assert.Equal(t, int64(1600), sync.Load())
+	assert.Equal(t, int64(1600), async.Load())
+}
+
+func TestBusCloseWhilePublishing(t *testing.T) {
+	bus := events.NewBus()
+
+	var handled atomic.Int64
+
+	err := bus.SubscribeAsync(func(events.Event) {
+		handled.Add(1)
+	}, 1)
+	assert.NoError(t, err)
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		for range 1000 {
+			bus.Publish(events.HintUsed{})
+		}
+	}()
+
+	bus.Close()
+	<-done
+
+	assert.LessOrEqual(t, handled.Load(), int64(1000))
+}
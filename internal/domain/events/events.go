@@ -0,0 +1,91 @@
+package events
+
+// Event описывает событие жизненного цикла игровой сессии.
+type Event interface {
+	// Name возвращает название события.
+	Name() string
+}
+
+// GameStarted - игра начата с указанным зерном генератора случайных чисел.
+type GameStarted struct {
+	Seed     uint64
+	Daily    bool
+	Practice bool
+}
+
+// ConditionsChosen - выбраны условия игры: категория, уровень сложности, тема и загаданное слово.
+type ConditionsChosen struct {
+	Category   string
+	Difficulty string
+	Theme      string
+	WordLength int
+	Attempts   int
+}
+
+// LetterGuessed - пользователь назвал букву. Positions содержит позиции, на которых буква открыта.
+type LetterGuessed struct {
+	Letter    rune
+	Hit       bool
+	Positions []int
+}
+
+// WordGuessed - пользователь назвал слово целиком.
+type WordGuessed struct {
+	Word string
+	Hit  bool
+}
+
+// HintUsed - пользователь запросил подсказку, и она показана. Number - номер подсказки, начиная с 1.
+// Если подсказок у слова нет, событие не публикуется.
+type HintUsed struct {
+	Hint   string
+	Number int
+}
+
+// AttemptLost - после ошибки потрачена попытка.
+type AttemptLost struct {
+	AttemptsLeft int
+}
+
+// GuessUndone - в тренировочной игре отменена последняя попытка. AttemptsLeft - количество попыток после отмены.
+type GuessUndone struct {
+	AttemptsLeft int
+}
+
+// GameWon - слово отгадано.
+type GameWon struct {
+	Word     string
+	Attempts int
+}
+
+// GameLost - попытки закончились, слово не отгадано.
+type GameLost struct {
+	Word string
+}
+
+// Name возвращает название события.
+func (GameStarted) Name() string { return "GameStarted" }
+
+// Name возвращает название события.
+func (ConditionsChosen) Name() string { return "ConditionsChosen" }
+
+// Name возвращает название события.
+func (LetterGuessed) Name() string { return "LetterGuessed" }
+
+// Name возвращает название события.
+func (WordGuessed) Name() string { return "WordGuessed" }
+
+// Name возвращает название события.
+func (HintUsed) Name() string { return "HintUsed" }
+
+// Name возвращает название события.
+func (AttemptLost) Name() string { return "AttemptLost" }
+
+// Name возвращает название события.
+func (GuessUndone) Name() string { return "GuessUndone" }
+
+// Name возвращает название события.
+func (GameWon) Name() string { return "GameWon" }
+
+// Name возвращает название события.
+func (GameLost) Name() string { return "GameLost" }
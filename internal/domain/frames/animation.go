@@ -0,0 +1,36 @@
+package frames
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// AnimationKey возвращает ключ под-анимации, которая проигрывается перед показом кадра index этапа stage,
+// например "process 5". Под-анимации хранятся в StageFramesMap наравне с этапами.
+func AnimationKey(stage string, index int) string {
+	return fmt.Sprintf("%s %d", stage, index)
+}
+
+// ParseAnimationKey разбирает ключ под-анимации на этап и номер кадра.
+// Если ключ не является ключом под-анимации известного этапа, возвращается false.
+func ParseAnimationKey(key string) (stage string, index int, ok bool) {
+	stage, number, ok := strings.Cut(key, " ")
+	if !ok || !slices.Contains(Stages, stage) {
+		return "", 0, false
+	}
+
+	index, err := strconv.Atoi(number)
+	if err != nil || index < 0 || strconv.Itoa(index) != number {
+		return "", 0, false
+	}
+
+	return stage, index, true
+}
+
+// Animation возвращает кадры под-анимации, которая проигрывается перед показом кадра index этапа stage,
+// или nil, если под-анимация не задана.
+func (sfm StageFramesMap) Animation(stage string, index int) []Frame {
+	return sfm[AnimationKey(stage, index)]
+}
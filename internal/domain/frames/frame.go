@@ -1,4 +1,87 @@
 package frames
 
-// Frame хранит кадр, состоящий из слайса строк.
-type Frame []string
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Frame хранит кадр, состоящий из слайса строк, задержку его показа в анимации в миллисекундах
+// и текстовое описание изображения для режима специальных возможностей.
+// Нулевая задержка означает задержку по-умолчанию из конфига.
+type Frame struct {
+	Lines       []string
+	MsDelay     int
+	Description string
+}
+
+// frameObject - запись кадра объектом: строки кадра, задержка его показа и описание.
+type frameObject struct {
+	Lines       json.RawMessage `json:"lines"`
+	MsDelay     int             `json:"msDelay,omitempty"`
+	Description string          `json:"description,omitempty"`
+}
+
+// UnmarshalJSON декодирует кадр из массива строк, из одной многострочной строки, например блочного скаляра YAML,
+// или из объекта со строками кадра в поле lines, задержкой в поле msDelay и описанием в поле description.
+// Завершающий перевод строки многострочной строки отбрасывается.
+func (f *Frame) UnmarshalJSON(data []byte) error {
+	var obj frameObject
+
+	if err := json.Unmarshal(data, &obj); err == nil {
+		lines, err := unmarshalLines(obj.Lines)
+		if err != nil {
+			return err
+		}
+
+		*f = Frame{Lines: lines, MsDelay: obj.MsDelay, Description: obj.Description}
+
+		return nil
+	}
+
+	lines, err := unmarshalLines(data)
+	if err != nil {
+		return err
+	}
+
+	*f = Frame{Lines: lines}
+
+	return nil
+}
+
+// MarshalJSON кодирует кадр массивом строк, а кадр с задержкой или описанием - объектом.
+func (f Frame) MarshalJSON() ([]byte, error) {
+	if f.MsDelay == 0 && f.Description == "" {
+		return json.Marshal(f.Lines)
+	}
+
+	lines, err := json.Marshal(f.Lines)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(frameObject{Lines: lines, MsDelay: f.MsDelay, Description: f.Description})
+}
+
+// String возвращает строки кадра в виде многострочной строки.
+func (f Frame) String() string {
+	return strings.Join(f.Lines, "\n")
+}
+
+// unmarshalLines декодирует строки кадра из массива строк или из одной многострочной строки.
+func unmarshalLines(data []byte) ([]string, error) {
+	var text string
+
+	if err := json.Unmarshal(data, &text); err == nil {
+		return strings.Split(strings.TrimSuffix(text, "\n"), "\n"), nil
+	}
+
+	var lines []string
+
+	err := json.Unmarshal(data, &lines)
+	if err != nil {
+		return nil, fmt.Errorf("frame must be an array of strings, a multi-line string or an object with lines: %w", err)
+	}
+
+	return lines, nil
+}
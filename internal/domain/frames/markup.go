@@ -0,0 +1,106 @@
+package frames
+
+import (
+	"slices"
+	"strings"
+)
+
+// Styles - названия стилей, которые можно использовать в разметке строк кадров: цвета и начертания.
+var Styles = []string{
+	"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white",
+	"bold", "dim", "italic", "underline", "reverse",
+}
+
+// markupReset - тег разметки, отменяющий все ранее заданные стили строки.
+const markupReset = "/"
+
+// Segment хранит фрагмент строки кадра и стили, которыми он оформлен.
+type Segment struct {
+	Text   string
+	Styles []string
+}
+
+// ParseMarkup разбивает строку кадра на фрагменты с одинаковым оформлением.
+// Тег вида {red} или {bold} добавляет стиль ко всему тексту после него, тег {/} отменяет все стили.
+// Фигурные скобки с любым другим содержимым считаются частью рисунка.
+func ParseMarkup(line string) []Segment {
+	var (
+		segments []Segment
+		styles   []string
+		text     strings.Builder
+	)
+
+	flush := func() {
+		if text.Len() != 0 {
+			segments = append(segments, Segment{Text: text.String(), Styles: slices.Clone(styles)})
+			text.Reset()
+		}
+	}
+
+	for line != "" {
+		name, rest, ok := cutTag(line)
+		if !ok {
+			text.WriteByte(line[0])
+			line = line[1:]
+
+			continue
+		}
+
+		flush()
+
+		if name == markupReset {
+			styles = nil
+		} else {
+			styles = append(styles, name)
+		}
+
+		line = rest
+	}
+
+	flush()
+
+	return segments
+}
+
+// PlainText возвращает строку кадра без тегов разметки.
+func PlainText(line string) string {
+	var sb strings.Builder
+
+	for _, segment := range ParseMarkup(line) {
+		sb.WriteString(segment.Text)
+	}
+
+	return sb.String()
+}
+
+// isStyledAtEnd возвращает true, если стили, заданные в строке кадра, не отменены тегом {/} к её концу, иначе false.
+func isStyledAtEnd(line string) bool {
+	styled := false
+
+	for line != "" {
+		name, rest, ok := cutTag(line)
+		if !ok {
+			line = line[1:]
+			continue
+		}
+
+		styled = name != markupReset
+		line = rest
+	}
+
+	return styled
+}
+
+// cutTag отделяет тег разметки в начале строки и возвращает название стиля и остаток строки.
+func cutTag(line string) (name, rest string, ok bool) {
+	if !strings.HasPrefix(line, "{") {
+		return "", line, false
+	}
+
+	name, rest, ok = strings.Cut(line[1:], "}")
+	if !ok || (name != markupReset && !slices.Contains(Styles, name)) {
+		return "", line, false
+	}
+
+	return name, rest, true
+}
@@ -0,0 +1,53 @@
+package frames_test
+
+import (
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/frames"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMarkup(t *testing.T) {
+	tt := []struct {
+		line     string
+		segments []frames.Segment
+	}{
+		{
+			line:     "  |   O",
+			segments: []frames.Segment{{Text: "  |   O", Styles: nil}},
+		},
+		{
+			line: "{red}/\\{bold}/\\{/} ~",
+			segments: []frames.Segment{
+				{Text: "/\\", Styles: []string{"red"}},
+				{Text: "/\\", Styles: []string{"red", "bold"}},
+				{Text: " ~", Styles: nil},
+			},
+		},
+		{
+			line:     "{ } {purple} {red",
+			segments: []frames.Segment{{Text: "{ } {purple} {red", Styles: nil}},
+		},
+		{
+			line:     "{red}{/}",
+			segments: nil,
+		},
+	}
+
+	for _, tc := range tt {
+		assert.Equal(t, tc.segments, frames.ParseMarkup(tc.line))
+	}
+}
+
+func TestFramePad(t *testing.T) {
+	fr := frames.Frame{Lines: []string{"{yellow}O{/}", "/|\\"}, MsDelay: 300}
+
+	width, height := fr.Size()
+	assert.Equal(t, 3, width)
+	assert.Equal(t, 2, height)
+
+	assert.Equal(t, frames.Frame{Lines: []string{"    ", "{yellow}O{/}   ", "/|\\ "}, MsDelay: 300}, fr.Pad(4, 3))
+
+	styled := frames.Frame{Lines: []string{"{red}{underline}==", "{bold}==={/}", "{red}==="}}
+	assert.Equal(t, []string{"{red}{underline}=={/} ", "{bold}==={/}", "{red}==="}, styled.Pad(3, 3).Lines)
+}
@@ -0,0 +1,57 @@
+package frames
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Size возвращает ширину кадра в символах без учёта тегов разметки и его высоту в строках.
+func (f Frame) Size() (width, height int) {
+	for _, line := range f.Lines {
+		width = max(width, visibleWidth(line))
+	}
+
+	return width, len(f.Lines)
+}
+
+// Pad возвращает копию кадра с теми же задержкой и описанием, дополненную пробелами справа до ширины width и пустыми строками сверху до высоты height,
+// чтобы кадры разной высоты выравнивались по нижнему краю. Стили, не отменённые к концу строки, отменяются перед пробелами,
+// чтобы оформление не распространялось на них. Строки и кадры больше заданного размера не обрезаются.
+func (f Frame) Pad(width, height int) Frame {
+	lines := make([]string, 0, max(height, len(f.Lines)))
+
+	for i := len(f.Lines); i < height; i++ {
+		lines = append(lines, strings.Repeat(" ", width))
+	}
+
+	for _, line := range f.Lines {
+		padding := max(0, width-visibleWidth(line))
+		if padding != 0 && isStyledAtEnd(line) {
+			line += "{" + markupReset + "}"
+		}
+
+		lines = append(lines, line+strings.Repeat(" ", padding))
+	}
+
+	padded := f
+	padded.Lines = lines
+
+	return padded
+}
+
+// Size возвращает наибольшие ширину и высоту кадров всех этапов.
+func (sfm StageFramesMap) Size() (width, height int) {
+	for _, frs := range sfm {
+		for _, fr := range frs {
+			w, h := fr.Size()
+			width, height = max(width, w), max(height, h)
+		}
+	}
+
+	return width, height
+}
+
+// visibleWidth возвращает количество выводимых на экран символов строки кадра.
+func visibleWidth(line string) int {
+	return utf8.RuneCountInString(PlainText(line))
+}
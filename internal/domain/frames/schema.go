@@ -0,0 +1,106 @@
+package frames
+
+import (
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/schema"
+)
+
+// Stages - этапы игры, кадры которых должны присутствовать в наборе кадров.
+var Stages = []string{"process", "victory", "defeat"}
+
+// Validate проверяет соответствие декодированного набора кадров схеме и возвращает все найденные нарушения.
+// Помимо этапов набор может содержать под-анимации кадров с ключами вида "process 5".
+func Validate(raw any, source string) schema.Problems {
+	var problems schema.Problems
+
+	stages, ok := schema.Object(&problems, source, schema.Root, raw)
+	if !ok {
+		return problems
+	}
+
+	var animations []string
+
+	for _, key := range schema.SortedKeys(stages) {
+		if _, _, ok := ParseAnimationKey(key); ok {
+			animations = append(animations, key)
+		}
+	}
+
+	schema.Fields(&problems, source, schema.Root, stages, Stages, animations)
+
+	for _, stage := range Stages {
+		if value, ok := stages[stage]; ok {
+			validateStage(&problems, source, schema.Key(schema.Root, stage), value)
+		}
+	}
+
+	for _, key := range animations {
+		path := schema.Key(schema.Root, key)
+		validateStage(&problems, source, path, stages[key])
+
+		stage, index, _ := ParseAnimationKey(key)
+		if frs, ok := stages[stage].([]any); ok && index >= len(frs) {
+			problems.Add(source, path, "stage %q has no frame %d", stage, index)
+		}
+	}
+
+	return problems
+}
+
+// validateStage проверяет, что этап содержит непустой список кадров.
+func validateStage(problems *schema.Problems, source, path string, raw any) {
+	frs, ok := schema.Array(problems, source, path, raw)
+	if !ok {
+		return
+	}
+
+	if len(frs) == 0 {
+		problems.Add(source, path, "stage must contain at least one frame")
+	}
+
+	for i, fr := range frs {
+		validateFrame(problems, source, schema.Index(path, i), fr)
+	}
+}
+
+// validateFrame проверяет, что кадр является массивом строк, многострочной строкой
+// или объектом со строками кадра в поле lines, неотрицательной задержкой в поле msDelay и описанием в поле description.
+func validateFrame(problems *schema.Problems, source, path string, raw any) {
+	if obj, ok := raw.(map[string]any); ok {
+		schema.Fields(problems, source, path, obj, []string{"lines"}, []string{"msDelay", "description"})
+
+		if lines, ok := obj["lines"]; ok {
+			validateLines(problems, source, schema.Key(path, "lines"), lines)
+		}
+
+		if delay, ok := obj["msDelay"]; ok {
+			delayPath := schema.Key(path, "msDelay")
+			if n, ok := schema.Int(problems, source, delayPath, delay); ok && n < 0 {
+				problems.Add(source, delayPath, "must not be negative, got %d", n)
+			}
+		}
+
+		if desc, ok := obj["description"]; ok {
+			schema.String(problems, source, schema.Key(path, "description"), desc)
+		}
+
+		return
+	}
+
+	validateLines(problems, source, path, raw)
+}
+
+// validateLines проверяет, что строки кадра записаны массивом строк или многострочной строкой.
+func validateLines(problems *schema.Problems, source, path string, raw any) {
+	if _, ok := raw.(string); ok {
+		return
+	}
+
+	lines, ok := schema.Array(problems, source, path, raw)
+	if !ok {
+		return
+	}
+
+	for j, line := range lines {
+		schema.String(problems, source, schema.Index(path, j), line)
+	}
+}
@@ -2,12 +2,3 @@ package frames
 
 // StageFramesMap - словарь, хранящий этапы игры и соответствующий им слайс кадров.
 type StageFramesMap map[string][]Frame
-
-// NewStageFramesMap возвращает инициализированный StageFramesMap, задавая этапам победы и поражения указанный размер кадра.
-func New(size int) StageFramesMap {
-	sfp := make(StageFramesMap)
-	sfp["victory"] = make([]Frame, size)
-	sfp["defeat"] = make([]Frame, size)
-
-	return sfp
-}
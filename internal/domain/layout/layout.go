@@ -0,0 +1,151 @@
+package layout
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Режимы исправления ввода в неверной раскладке клавиатуры.
+const (
+	// ModeAsk - предлагать пользователю заменить символ буквой активного алфавита.
+	ModeAsk = "ask"
+	// ModeConvert - заменять символ буквой активного алфавита без подтверждения.
+	ModeConvert = "convert"
+	// ModeOff - принимать символ как есть.
+	ModeOff = "off"
+)
+
+// Modes - допустимые режимы исправления ввода в неверной раскладке клавиатуры.
+var Modes = []string{ModeAsk, ModeConvert, ModeOff}
+
+// Layouts - словарь, сопоставляющий названию раскладки клавиатуры символы её клавиш в нижнем регистре,
+// перечисленные в одном и том же порядке расположения клавиш для всех раскладок.
+type Layouts map[string]string
+
+// Converter переводит символы, набранные в другой раскладке, в буквы алфавита загаданного слова.
+type Converter struct {
+	alphabet map[rune]struct{}
+	table    map[rune]rune
+}
+
+// NewConverter возвращает Converter для слова word. Активной считается раскладка, содержащая больше всего букв слова;
+// её буквы вместе с символами самого слова составляют алфавит слова. Символы клавиш других раскладок,
+// не входящие в алфавит, сопоставляются буквам активной раскладки на тех же клавишах.
+func NewConverter(lts Layouts, word string) Converter {
+	c := Converter{
+		alphabet: make(map[rune]struct{}),
+		table:    make(map[rune]rune),
+	}
+
+	for _, r := range word {
+		c.alphabet[unicode.ToLower(r)] = struct{}{}
+	}
+
+	active := activeLayout(lts, c.alphabet)
+	if active == "" {
+		return c
+	}
+
+	keys := []rune(lts[active])
+
+	for _, r := range keys {
+		if unicode.IsLetter(r) {
+			c.alphabet[r] = struct{}{}
+		}
+	}
+
+	for _, name := range sortedNames(lts) {
+		if name == active {
+			continue
+		}
+
+		for i, r := range []rune(lts[name]) {
+			if i >= len(keys) || !unicode.IsLetter(keys[i]) {
+				continue
+			}
+
+			if _, ok := c.alphabet[r]; ok {
+				continue
+			}
+
+			if _, ok := c.table[r]; !ok {
+				c.table[r] = keys[i]
+			}
+		}
+	}
+
+	return c
+}
+
+// Convert возвращает букву алфавита слова, набранную на той же клавише, что и символ r, и true,
+// если r не входит в алфавит слова и набран в другой раскладке, иначе r и false.
+func (c Converter) Convert(r rune) (rune, bool) {
+	if _, ok := c.alphabet[r]; ok {
+		return r, false
+	}
+
+	converted, ok := c.table[r]
+	if !ok {
+		return r, false
+	}
+
+	return converted, true
+}
+
+// ConvertWord возвращает слово, в котором символы, набранные в другой раскладке, заменены буквами алфавита слова
+// на тех же клавишах, и true, если заменён хотя бы один символ, иначе word и false.
+func (c Converter) ConvertWord(word string) (string, bool) {
+	var (
+		sb        strings.Builder
+		converted bool
+	)
+
+	for _, r := range word {
+		r, ok := c.Convert(r)
+		converted = converted || ok
+
+		sb.WriteRune(r)
+	}
+
+	if !converted {
+		return word, false
+	}
+
+	return sb.String(), true
+}
+
+// activeLayout возвращает название раскладки, содержащей больше всего букв алфавита,
+// или пустую строку, если ни одна раскладка не содержит букв алфавита.
+// При равенстве выбирается раскладка с меньшим в лексикографическом порядке названием.
+func activeLayout(lts Layouts, alphabet map[rune]struct{}) string {
+	active, best := "", 0
+
+	for _, name := range sortedNames(lts) {
+		count := 0
+
+		for _, r := range lts[name] {
+			if _, ok := alphabet[r]; ok && unicode.IsLetter(r) {
+				count++
+			}
+		}
+
+		if count > best {
+			active, best = name, count
+		}
+	}
+
+	return active
+}
+
+// sortedNames возвращает упорядоченные названия раскладок.
+func sortedNames(lts Layouts) []string {
+	names := make([]string, 0, len(lts))
+	for name := range lts {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,71 @@
+package layout_test
+
+import (
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/layout"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConverter(t *testing.T) {
+	lts := layout.Layouts{
+		"qwerty": "`qwertyuiop[]asdfghjkl;'zxcvbnm,.",
+		"йцукен": "ёйцукенгшщзхъфывапролджэячсмитьбю",
+	}
+
+	tests := []struct {
+		name      string
+		word      string
+		input     rune
+		expected  rune
+		converted bool
+	}{
+		{name: "latin letter in cyrillic word", word: "вишня", input: 'd', expected: 'в', converted: true},
+		{name: "punctuation key in cyrillic word", word: "вишня", input: ';', expected: 'ж', converted: true},
+		{name: "cyrillic letter in cyrillic word", word: "вишня", input: 'ж', expected: 'ж', converted: false},
+		{name: "cyrillic letter in latin word", word: "apple", input: 'ф', expected: 'a', converted: true},
+		{name: "letter of the word is kept", word: "яblоко", input: 'b', expected: 'b', converted: false},
+		{name: "unknown symbol", word: "вишня", input: '1', expected: '1', converted: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := layout.NewConverter(lts, tt.word)
+
+			actual, converted := c.Convert(tt.input)
+			assert.Equal(t, tt.expected, actual)
+			assert.Equal(t, tt.converted, converted)
+		})
+	}
+}
+
+func TestConverterWord(t *testing.T) {
+	lts := layout.Layouts{
+		"qwerty": "`qwertyuiop[]asdfghjkl;'zxcvbnm,.",
+		"йцукен": "ёйцукенгшщзхъфывапролджэячсмитьбю",
+	}
+
+	tests := []struct {
+		name      string
+		word      string
+		input     string
+		expected  string
+		converted bool
+	}{
+		{name: "latin word in cyrillic word", word: "вишня", input: "dbiyz", expected: "вишня", converted: true},
+		{name: "mixed layouts", word: "вишня", input: "вbшyя", expected: "вишня", converted: true},
+		{name: "punctuation keys", word: "ёж", input: "`;", expected: "ёж", converted: true},
+		{name: "cyrillic word is kept", word: "вишня", input: "черешня", expected: "черешня", converted: false},
+		{name: "unknown symbols are kept", word: "шар-пей", input: "ifh-gtq", expected: "шар-пей", converted: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := layout.NewConverter(lts, tt.word)
+
+			actual, converted := c.ConvertWord(tt.input)
+			assert.Equal(t, tt.expected, actual)
+			assert.Equal(t, tt.converted, converted)
+		})
+	}
+}
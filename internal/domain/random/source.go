@@ -0,0 +1,58 @@
+package random
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	mrand "math/rand/v2"
+)
+
+// ErrInvalidRange - верхняя граница полуинтервала случайных чисел не положительна.
+var ErrInvalidRange = errors.New("invalid random range")
+
+// Source описывает источник случайных чисел, зерно которого можно сообщить для воспроизведения игры.
+type Source interface {
+	Int(maxValue int) (int, error)
+	Seed() uint64
+}
+
+// Seeded реализует Source на основе детерминированного генератора PCG.
+type Seeded struct {
+	rnd  *mrand.Rand
+	seed uint64
+}
+
+// New возвращает указатель на инициализированную структуру Seeded с переданным зерном.
+func New(seed uint64) *Seeded {
+	return &Seeded{
+		rnd:  mrand.New(mrand.NewPCG(seed, seed)),
+		seed: seed,
+	}
+}
+
+// NewSeed возвращает случайное зерно, полученное из crypto/rand.
+func NewSeed() (uint64, error) {
+	var buf [8]byte
+
+	_, err := rand.Read(buf[:])
+	if err != nil {
+		return 0, fmt.Errorf("can`t read random bytes: %w", err)
+	}
+
+	return binary.LittleEndian.Uint64(buf[:]), nil
+}
+
+// Int возвращает случайное число из полуинтервала [0, maxValue). Если maxValue <= 0, возвращает ErrInvalidRange.
+func (s *Seeded) Int(maxValue int) (int, error) {
+	if maxValue <= 0 {
+		return 0, fmt.Errorf("%w: max value must be positive, got %d", ErrInvalidRange, maxValue)
+	}
+
+	return s.rnd.IntN(maxValue), nil
+}
+
+// Seed возвращает зерно, которым был инициализирован генератор.
+func (s *Seeded) Seed() uint64 {
+	return s.seed
+}
@@ -0,0 +1,110 @@
+package replay
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Version - текущая версия формата записи игры. При изменении формата версия увеличивается,
+// а в migrations добавляется функция, переводящая запись предыдущей версии в новую.
+const Version = 2
+
+// Виды записанного ввода.
+const (
+	// InputLetter - ввод буквы, слова целиком или команды вместо них.
+	InputLetter = "letter"
+	// InputConfirm - ответ на предложение исправить раскладку клавиатуры.
+	InputConfirm = "confirm"
+)
+
+// Input хранит строку ввода пользователя, её вид и время ввода в миллисекундах от начала игры.
+type Input struct {
+	Ms    int64  `json:"ms"`
+	Kind  string `json:"kind"`
+	Value string `json:"value"`
+}
+
+// Replay хранит запись игры: версию формата, зерно генератора, загаданное слово с подсказками, пояснением и источником,
+// условия игры, номера кадров раскадровки, режимы, влияющие на ввод, слова, названные целиком и не принятые, потому что
+// их нет в списке слов языка, и последовательность ввода пользователя. Непринятые слова записываются, чтобы запись
+// воспроизводилась одинаково независимо от текущего списка слов. Зерно записывается строкой, чтобы не терять точность
+// при декодировании чисел.
+type Replay struct {
+	Version      int      `json:"version"`
+	Seed         uint64   `json:"seed,string"`
+	Word         string   `json:"word"`
+	Hints        []string `json:"hints"`
+	Explanation  string   `json:"explanation,omitempty"`
+	Source       string   `json:"source,omitempty"`
+	Category     string   `json:"category"`
+	Difficulty   string   `json:"difficulty"`
+	Theme        string   `json:"theme"`
+	Attempts     int      `json:"attempts"`
+	FrameIndexes []int    `json:"frameIndexes"`
+	LayoutMode   string   `json:"layoutMode"`
+	Practice     bool     `json:"practice,omitempty"`
+	UnknownWords []string `json:"unknownWords,omitempty"`
+	Inputs       []Input  `json:"inputs"`
+}
+
+// ErrUnsupportedVersion - версия записи новее поддерживаемой или не может быть переведена в текущую.
+var ErrUnsupportedVersion = errors.New("unsupported replay version")
+
+// migrations сопоставляет версии формата функцию, переводящую декодированную запись этой версии в следующую.
+var migrations = map[int]func(data map[string]any) error{
+	1: migrateHints,
+}
+
+// Migrate переводит декодированную запись игры любой поддерживаемой версии в текущую версию формата.
+func Migrate(raw any) (map[string]any, error) {
+	data, ok := raw.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("replay must be an object, got %T", raw)
+	}
+
+	number, ok := data["version"].(float64)
+	if !ok {
+		return nil, fmt.Errorf("%w: version is missing", ErrUnsupportedVersion)
+	}
+
+	version := int(number)
+	if version < 1 || version > Version {
+		return nil, fmt.Errorf("%w %d, expected 1..%d", ErrUnsupportedVersion, version, Version)
+	}
+
+	for ; version < Version; version++ {
+		migrate, ok := migrations[version]
+		if !ok {
+			return nil, fmt.Errorf("%w %d: no migration to version %d", ErrUnsupportedVersion, version, version+1)
+		}
+
+		err := migrate(data)
+		if err != nil {
+			return nil, fmt.Errorf("can`t migrate replay from version %d: %w", version, err)
+		}
+
+		data["version"] = float64(version + 1)
+	}
+
+	return data, nil
+}
+
+// migrateHints переводит запись версии 1 с единственной подсказкой в поле hint в версию 2 со списком подсказок hints.
+func migrateHints(data map[string]any) error {
+	hints := []any{}
+
+	switch hint := data["hint"].(type) {
+	case nil:
+	case string:
+		if hint != "" {
+			hints = append(hints, hint)
+		}
+	default:
+		return fmt.Errorf("hint must be a string, got %T", hint)
+	}
+
+	delete(data, "hint")
+	data["hints"] = hints
+
+	return nil
+}
@@ -0,0 +1,29 @@
+package replay_test
+
+import (
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/replay"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrate(t *testing.T) {
+	current := map[string]any{"version": float64(replay.Version), "word": "вишня"}
+
+	data, err := replay.Migrate(current)
+	assert.NoError(t, err)
+	assert.Equal(t, current, data)
+
+	_, err = replay.Migrate(map[string]any{"version": float64(replay.Version + 1)})
+	assert.ErrorIs(t, err, replay.ErrUnsupportedVersion)
+
+	_, err = replay.Migrate(map[string]any{"word": "вишня"})
+	assert.ErrorIs(t, err, replay.ErrUnsupportedVersion)
+
+	_, err = replay.Migrate([]any{})
+	assert.Error(t, err)
+
+	data, err = replay.Migrate(map[string]any{"version": float64(1), "word": "вишня", "hint": "ягода"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"version": float64(2), "word": "вишня", "hints": []any{"ягода"}}, data)
+}
@@ -0,0 +1,49 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Problem описывает нарушение схемы: источник данных, путь JSON к значению и сообщение.
+type Problem struct {
+	Source  string
+	Path    string
+	Message string
+}
+
+// Error возвращает описание нарушения в виде "источник: путь: сообщение".
+func (p Problem) Error() string {
+	return p.Source + ": " + p.Path + ": " + p.Message
+}
+
+// Problems - список нарушений схемы, реализующий error.
+type Problems []Problem
+
+// Add добавляет нарушение, форматируя сообщение.
+func (ps *Problems) Add(source, path, format string, a ...any) {
+	*ps = append(*ps, Problem{
+		Source:  source,
+		Path:    path,
+		Message: fmt.Sprintf(format, a...),
+	})
+}
+
+// Error возвращает описания всех нарушений, по одному на строке.
+func (ps Problems) Error() string {
+	lines := make([]string, 0, len(ps))
+	for _, p := range ps {
+		lines = append(lines, p.Error())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Err возвращает список нарушений как ошибку или nil, если нарушений нет.
+func (ps Problems) Err() error {
+	if len(ps) == 0 {
+		return nil
+	}
+
+	return ps
+}
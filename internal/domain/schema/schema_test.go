@@ -0,0 +1,138 @@
+package schema_test
+
+import (
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaths(t *testing.T) {
+	assert.Equal(t, "$.words", schema.Key(schema.Root, "words"))
+	assert.Equal(t, "$.words[2]", schema.Index(schema.Key(schema.Root, "words"), 2))
+	assert.Equal(t, "$.words[2].hints[0]", schema.Index(schema.Key(schema.Index("$.words", 2), "hints"), 0))
+}
+
+func TestProblems(t *testing.T) {
+	var ps schema.Problems
+
+	assert.NoError(t, ps.Err())
+
+	ps.Add("words.json", "$.пища", "expected %s, got %d", "object", 1)
+	ps.Add("config.json", "$.lang", "must not be empty")
+
+	assert.Equal(t, schema.Problems{
+		{Source: "words.json", Path: "$.пища", Message: "expected object, got 1"},
+		{Source: "config.json", Path: "$.lang", Message: "must not be empty"},
+	}, ps)
+	assert.EqualError(t, ps.Err(), "words.json: $.пища: expected object, got 1\nconfig.json: $.lang: must not be empty")
+}
+
+func TestValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		check    func(ps *schema.Problems, value any) bool
+		value    any
+		ok       bool
+		expected string
+	}{
+		{name: "object", check: isObject, value: map[string]any{}, ok: true},
+		{name: "object from array", check: isObject, value: []any{}, expected: "expected object, got array"},
+		{name: "array", check: isArray, value: []any{"кот"}, ok: true},
+		{name: "array from null", check: isArray, value: nil, expected: "expected array, got null"},
+		{name: "string", check: isString, value: "кот", ok: true},
+		{name: "string from boolean", check: isString, value: true, expected: "expected string, got boolean"},
+		{name: "integer", check: isInt, value: int64(7), ok: true},
+		{name: "integral float", check: isInt, value: 7.0, ok: true},
+		{name: "fractional float", check: isInt, value: 7.5, expected: "expected integer, got number"},
+		{name: "integer from string", check: isInt, value: "7", expected: "expected integer, got string"},
+		{name: "number", check: isNumber, value: 1.5, ok: true},
+		{name: "number from object", check: isNumber, value: map[string]any{}, expected: "expected number, got object"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ps schema.Problems
+
+			assert.Equal(t, tt.ok, tt.check(&ps, tt.value))
+
+			if tt.ok {
+				assert.Empty(t, ps)
+				return
+			}
+
+			assert.Equal(t, schema.Problems{{Source: "file.json", Path: "$.value", Message: tt.expected}}, ps)
+		})
+	}
+}
+
+func TestFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		obj      map[string]any
+		expected schema.Problems
+	}{
+		{
+			name: "valid",
+			obj:  map[string]any{"word": "кот", "hints": []any{}},
+		},
+		{
+			name: "missing required field",
+			obj:  map[string]any{"hints": []any{}},
+			expected: schema.Problems{
+				{Source: "words.json", Path: "$.пища[0].word", Message: "required field is missing"},
+			},
+		},
+		{
+			name: "unknown fields in order",
+			obj:  map[string]any{"word": "кот", "tag": "", "Hints": []any{}},
+			expected: schema.Problems{
+				{Source: "words.json", Path: "$.пища[0].Hints", Message: "unknown field"},
+				{Source: "words.json", Path: "$.пища[0].tag", Message: "unknown field"},
+			},
+		},
+		{
+			name: "missing and unknown fields",
+			obj:  map[string]any{"wrod": "кот"},
+			expected: schema.Problems{
+				{Source: "words.json", Path: "$.пища[0].word", Message: "required field is missing"},
+				{Source: "words.json", Path: "$.пища[0].wrod", Message: "unknown field"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ps schema.Problems
+
+			schema.Fields(&ps, "words.json", "$.пища[0]", tt.obj, []string{"word"}, []string{"hints"})
+
+			assert.Equal(t, tt.expected, ps)
+		})
+	}
+}
+
+func isObject(ps *schema.Problems, value any) bool {
+	_, ok := schema.Object(ps, "file.json", "$.value", value)
+	return ok
+}
+
+func isArray(ps *schema.Problems, value any) bool {
+	_, ok := schema.Array(ps, "file.json", "$.value", value)
+	return ok
+}
+
+func isString(ps *schema.Problems, value any) bool {
+	_, ok := schema.String(ps, "file.json", "$.value", value)
+	return ok
+}
+
+func isInt(ps *schema.Problems, value any) bool {
+	_, ok := schema.Int(ps, "file.json", "$.value", value)
+	return ok
+}
+
+func isNumber(ps *schema.Problems, value any) bool {
+	_, ok := schema.Number(ps, "file.json", "$.value", value)
+	return ok
+}
@@ -0,0 +1,139 @@
+package schema
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Root - путь JSON к корню документа.
+const Root = "$"
+
+// Key возвращает путь JSON к полю объекта.
+func Key(path, key string) string {
+	return path + "." + key
+}
+
+// Index возвращает путь JSON к элементу массива.
+func Index(path string, i int) string {
+	return fmt.Sprintf("%s[%d]", path, i)
+}
+
+// Object приводит декодированное значение к объекту, добавляя нарушение при несоответствии типа.
+func Object(ps *Problems, source, path string, value any) (map[string]any, bool) {
+	obj, ok := value.(map[string]any)
+	if !ok {
+		ps.Add(source, path, "expected object, got %s", typeName(value))
+	}
+
+	return obj, ok
+}
+
+// Array приводит декодированное значение к массиву, добавляя нарушение при несоответствии типа.
+func Array(ps *Problems, source, path string, value any) ([]any, bool) {
+	arr, ok := value.([]any)
+	if !ok {
+		ps.Add(source, path, "expected array, got %s", typeName(value))
+	}
+
+	return arr, ok
+}
+
+// String приводит декодированное значение к строке, добавляя нарушение при несоответствии типа.
+func String(ps *Problems, source, path string, value any) (string, bool) {
+	str, ok := value.(string)
+	if !ok {
+		ps.Add(source, path, "expected string, got %s", typeName(value))
+	}
+
+	return str, ok
+}
+
+// Int приводит декодированное значение к целому числу, добавляя нарушение при несоответствии типа.
+func Int(ps *Problems, source, path string, value any) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case int64:
+		return int(v), true
+	case float64:
+		if v == math.Trunc(v) {
+			return int(v), true
+		}
+	}
+
+	ps.Add(source, path, "expected integer, got %s", typeName(value))
+
+	return 0, false
+}
+
+// Number приводит декодированное значение к числу, добавляя нарушение при несоответствии типа.
+func Number(ps *Problems, source, path string, value any) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+
+	ps.Add(source, path, "expected number, got %s", typeName(value))
+
+	return 0, false
+}
+
+// Fields проверяет, что объект содержит только разрешённые поля и все обязательные поля.
+func Fields(ps *Problems, source, path string, obj map[string]any, required, optional []string) {
+	allowed := make(map[string]struct{}, len(required)+len(optional))
+
+	for _, key := range required {
+		allowed[key] = struct{}{}
+
+		if _, ok := obj[key]; !ok {
+			ps.Add(source, Key(path, key), "required field is missing")
+		}
+	}
+
+	for _, key := range optional {
+		allowed[key] = struct{}{}
+	}
+
+	for _, key := range SortedKeys(obj) {
+		if _, ok := allowed[key]; !ok {
+			ps.Add(source, Key(path, key), "unknown field")
+		}
+	}
+}
+
+// SortedKeys возвращает упорядоченные ключи объекта, чтобы нарушения выводились в стабильном порядке.
+func SortedKeys[T any](obj map[string]T) []string {
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// typeName возвращает название типа декодированного значения в терминах JSON.
+func typeName(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case int, int64, float64:
+		return "number"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
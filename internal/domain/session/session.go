@@ -1,37 +1,99 @@
 package session
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/answer"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/conditions"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/daily"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/dictionary"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/events"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/frames"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/layout"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/random"
-	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/status"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/replay"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/theme"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/words"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/view/storyboard"
+	"github.com/es-debug/backend-academy-2024-go-template/pkg/hangman"
 )
 
-// Session хранит ответ, текущее состояние ответа, максимальное количество попыток, раскадровку,
-// множество использованных букв и использует интерфейс console.
+// Session хранит ответ, игру движка hangman, максимальное количество попыток, тему, раскадровку,
+// историю попаданий и использует интерфейсы console и random.Source. Правила игры реализует движок,
+// а сессия связывает его с консолью.
+// Если задано ежедневное испытание, условия выбираются без участия пользователя.
+// Заранее заданные категория, уровень сложности и тема не запрашиваются у пользователя.
+// Вместо одной категории можно задать фильтр слов: несколько категорий, теги, длину слова и другие условия.
+// Уже встречавшиеся пользователю слова исключаются фильтром по его выбору.
+// Заранее заданное слово загадывается вместо выбора слова из словаря, и категория не запрашивается.
+// Слова, называемые целиком, проверяются по списку слов языка, если он задан.
+// Слово выбирается стратегией selector, а в ежедневном испытании - равновероятно, чтобы оно было одинаковым у всех игроков.
+// Буквы, набранные в раскладке клавиатуры, отличной от раскладки слова, исправляются согласно режиму layoutMode.
+// В тренировочной игре перед каждой попыткой в историю сохраняется снимок хода, чтобы попытку можно было отменить.
+// События жизненного цикла сессии публикуются в шину событий.
+// Если задана запись игры, слово, условия и раскадровка берутся из неё без участия словаря и генератора.
 type Session struct {
-	console     console
-	answer      answer.Answer
-	status      status.Status
-	maxAttmeps  int
-	storyboard  frames.StageFramesMap
-	lettersUsed map[rune]struct{}
+	console          console
+	random           random.Source
+	selector         words.Selector
+	answer           answer.Answer
+	game             *hangman.Game
+	maxAttmeps       int
+	theme            theme.Theme
+	themeName        string
+	frameIndexes     []int
+	storyboard       frames.StageFramesMap
+	guesses          []bool
+	challenge        *daily.Challenge
+	presetCategory   *string
+	presetDifficulty *string
+	presetTheme      *string
+	presetQuery      *words.Query
+	presetWord       *string
+	dictionary       *dictionary.Dictionary
+	unknownWords     []string
+	seen             map[string]struct{}
+	layoutMode       string
+	layouts          layout.Layouts
+	converter        layout.Converter
+	practice         bool
+	history          []snapshot
+	events           *events.Bus
+	replay           *replay.Replay
 }
 
+var (
+	// ErrNoConditions - не из чего выбрать категорию, уровень сложности или тему.
+	ErrNoConditions = errors.New("no conditions to choose from")
+	// ErrReplayMismatch - запись игры не соответствует кадрам темы.
+	ErrReplayMismatch = errors.New("replay doesn`t match theme")
+)
+
+// Команды, которые можно ввести вместо буквы.
+const (
+	hintCommand = '?'
+	undoCommand = '<'
+)
+
 // console описывает интерфейс консоли.
 type console interface {
-	ChooseConditions(
-		categories conditions.Categories,
-		dfs conditions.Difficulties,
-		randomSelectionCommand string,
-	) (category, difficulty string, err error)
-	Enter() (letter rune, err error)
-	DisplayHint(hint string)
+	ChooseWords(cts conditions.Categories, tags []string, randomSelectionCommand string) (q words.Query, excludeSeen bool, err error)
+	ChooseDifficulty(dfs conditions.Difficulties, randomSelectionCommand string) (difficulty string, err error)
+	ChooseTheme(ths theme.Themes, randomSelectionCommand string) (name string, err error)
+	SetColors(colors map[string]string)
+	Enter() (guess string, err error)
+	ConfirmLayout(typed, converted string) (ok bool, err error)
+	ConfirmUndo() (ok bool, err error)
+	DisplayLayoutConverted(typed, converted string)
+	DisplayHint(hint string, number, total int)
+	DisplayNothingToUndo()
+	DisplayError(err error)
 	DisplaySessionStatus(
 		category, difficulty string,
 		fr frames.Frame,
@@ -39,153 +101,710 @@ type console interface {
 		attempts int,
 		lettersUsed map[rune]struct{},
 	)
-	PlayAnimation(frs []frames.Frame, msDelay int)
+	PlayAnimation(ctx context.Context, stage string, frs []frames.Frame, msDelay int)
+	PlayTransition(ctx context.Context, frs []frames.Frame, msDelay int)
+	DisplayMessage(message string)
+	DisplayExplanation(explanation, source string)
+	DisplaySummary(summary string)
+	DisplaySeed(seed uint64)
 }
 
-// New возвращает инициализированную структуру Session с переданной консолью и пустым множеством использованных букв.
-func New(console console) Session {
+// New возвращает инициализированную структуру Session с переданными консолью, источником случайных чисел
+// и шиной событий без подписчиков.
+func New(console console, rnd random.Source) Session {
 	return Session{
-		console:     console,
-		lettersUsed: make(map[rune]struct{}),
+		console:  console,
+		random:   rnd,
+		selector: words.Uniform{},
+		events:   events.NewBus(),
+	}
+}
+
+// NewDaily возвращает инициализированную структуру Session для ежедневного испытания,
+// источник случайных чисел которой инициализирован зерном испытания.
+func NewDaily(console console, challenge daily.Challenge) Session {
+	s := New(console, random.New(challenge.Seed))
+	s.challenge = &challenge
+
+	return s
+}
+
+// SetSelector задаёт стратегию выбора слова. По-умолчанию слово выбирается равновероятно.
+func (s *Session) SetSelector(selector words.Selector) {
+	s.selector = selector
+}
+
+// PresetCategory задаёт категорию, которая не будет запрашиваться у пользователя.
+func (s *Session) PresetCategory(category string) {
+	s.presetCategory = &category
+}
+
+// PresetDifficulty задаёт уровень сложности, который не будет запрашиваться у пользователя.
+func (s *Session) PresetDifficulty(difficulty string) {
+	s.presetDifficulty = &difficulty
+}
+
+// PresetTheme задаёт тему оформления, которая не будет запрашиваться у пользователя.
+func (s *Session) PresetTheme(name string) {
+	s.presetTheme = &name
+}
+
+// PresetQuery задаёт фильтр слов, который заменяет выбор категории пользователем.
+func (s *Session) PresetQuery(q words.Query) {
+	s.presetQuery = &q
+}
+
+// PresetWord задаёт слово, которое будет загадано вместо выбора слова из словаря. Категория при этом не запрашивается.
+func (s *Session) PresetWord(word string) {
+	s.presetWord = &word
+}
+
+// SetDictionary задаёт список слов языка, по которому проверяются слова, называемые целиком:
+// неверное слово, которого нет в списке, не принимается. Слова, не принятые в записи игры, не принимаются
+// и без списка.
+func (s *Session) SetDictionary(d *dictionary.Dictionary) {
+	s.dictionary = d
+}
+
+// SetSeen задаёт множество уже встречавшихся пользователю слов в нижнем регистре,
+// которые исключаются из выбора, если пользователь настроит это в фильтре.
+func (s *Session) SetSeen(seen map[string]struct{}) {
+	s.seen = seen
+}
+
+// PresetReplay задаёт запись игры, по которой сессия воспроизводит слово, условия и раскадровку.
+func (s *Session) PresetReplay(rp replay.Replay) {
+	s.replay = &rp
+	s.practice = rp.Practice
+}
+
+// Recording возвращает запись сыгранной игры без последовательности ввода.
+func (s *Session) Recording() replay.Replay {
+	return replay.Replay{
+		Version:      replay.Version,
+		Seed:         s.random.Seed(),
+		Word:         s.answer.Word,
+		Hints:        slices.Clone(s.answer.Hints),
+		Explanation:  s.answer.Explanation,
+		Source:       s.answer.Source,
+		Category:     s.answer.Category,
+		Difficulty:   s.answer.Difficulty,
+		Theme:        s.themeName,
+		Attempts:     s.maxAttmeps,
+		FrameIndexes: slices.Clone(s.frameIndexes),
+		LayoutMode:   s.layoutMode,
+		Practice:     s.practice,
+		UnknownWords: slices.Clone(s.unknownWords),
 	}
 }
 
-// Play запускает игровую сессию.
+// Events возвращает шину, в которую публикуются события сессии.
+func (s *Session) Events() *events.Bus {
+	return s.events
+}
+
+// EnablePractice делает игру тренировочной: последнюю попытку можно отменить командой отмены.
+func (s *Session) EnablePractice() {
+	s.practice = true
+}
+
+// SetKeyboardLayouts задаёт раскладки клавиатуры и режим исправления букв, набранных в неверной раскладке.
+func (s *Session) SetKeyboardLayouts(mode string, lts layout.Layouts) {
+	s.layoutMode = mode
+	s.layouts = lts
+}
+
+// Conditions возвращает категорию и уровень сложности сыгранного слова.
+func (s *Session) Conditions() (category, difficulty string) {
+	return s.answer.Category, s.answer.Difficulty
+}
+
+// Word возвращает загаданное слово в нижнем регистре или пустую строку, если слово ещё не загадано.
+func (s *Session) Word() string {
+	return s.answer.Word
+}
+
+// IsConfigured возвращает true, если условия игры выбраны и слово загадано, иначе false.
+func (s *Session) IsConfigured() bool {
+	return s.game != nil
+}
+
+// IsWon возвращает true, если слово отгадано, иначе false.
+func (s *Session) IsWon() bool {
+	return s.game != nil && s.game.Status() == hangman.Won
+}
+
+// Summary возвращает результат ежедневного испытания или пустую строку, если сессия не является испытанием.
+func (s *Session) Summary() string {
+	if s.challenge == nil {
+		return ""
+	}
+
+	return s.challenge.Summary(s.guesses, s.IsWon(), s.maxAttmeps)
+}
+
+// Play запускает игровую сессию. Отмена ctx прерывает проигрываемые анимации.
 func (s *Session) Play(
+	ctx context.Context,
 	ws words.Words,
 	dfs conditions.Difficulties,
 	randomSelectionCommand string,
-	msFrameDelay int,
-	sfm frames.StageFramesMap,
+	msFrameDelay, msTransitionDelay int,
+	ths theme.Themes,
 ) error {
-	err := s.configure(ws, dfs, randomSelectionCommand, sfm)
+	s.events.Publish(events.GameStarted{
+		Seed:     s.random.Seed(),
+		Daily:    s.challenge != nil,
+		Practice: s.practice,
+	})
+
+	var err error
+
+	if s.replay != nil {
+		err = s.configureReplay(ths)
+	} else {
+		err = s.configure(ws, dfs, randomSelectionCommand, ths)
+	}
+
 	if err != nil {
 		return fmt.Errorf("can`t configure session: %w", err)
 	}
 
-	attempts := s.maxAttmeps
-
-	for !s.status.IsGuessed() && attempts != 0 {
-		attempts, err = s.playRound(attempts)
+	for s.game.Status() == hangman.InProgress {
+		err = s.playRound(ctx, msTransitionDelay)
 		if err != nil {
 			return fmt.Errorf("can`t play round: %w", err)
 		}
+
+		if s.game.Status() == hangman.InProgress {
+			continue
+		}
+
+		err = s.offerUndo()
+		if err != nil {
+			return fmt.Errorf("can`t offer undo: %w", err)
+		}
 	}
 
-	if s.status.IsGuessed() {
-		s.console.PlayAnimation(s.storyboard["victory"], msFrameDelay)
+	if s.IsWon() {
+		s.events.Publish(events.GameWon{Word: s.answer.Word, Attempts: s.game.State().AttemptsLeft})
+		s.console.PlayAnimation(ctx, "victory", s.storyboard["victory"], msFrameDelay)
 	} else {
-		s.console.PlayAnimation(s.storyboard["defeat"], msFrameDelay)
+		s.events.Publish(events.GameLost{Word: s.answer.Word})
+		s.console.PlayAnimation(ctx, "defeat", s.storyboard["defeat"], msFrameDelay)
+	}
+
+	if message := s.theme.Message(s.IsWon()); message != "" {
+		s.console.DisplayMessage(message)
 	}
 
+	if s.answer.Explanation != "" || s.answer.Source != "" {
+		s.console.DisplayExplanation(s.answer.Explanation, s.answer.Source)
+	}
+
+	if s.challenge != nil {
+		s.console.DisplaySummary(s.Summary())
+	}
+
+	s.console.DisplaySeed(s.random.Seed())
+
 	return nil
 }
 
-// configure конфигурирует игровую сессию на основе выбора пользователем категории и уровня сложности.
+// configure конфигурирует игровую сессию на основе выбора пользователем категории, уровня сложности и темы.
 func (s *Session) configure(
 	ws words.Words,
 	dfs conditions.Difficulties,
 	randomSelectionCommand string,
-	sfm frames.StageFramesMap,
+	ths theme.Themes,
 ) error {
 	cts := conditions.NewCategories(ws)
 
-	category, difficulty, err := s.console.ChooseConditions(cts, dfs, randomSelectionCommand)
+	if s.challenge != nil {
+		s.presetQuery = nil
+		s.presetWord = nil
+		s.PresetCategory(randomSelectionCommand)
+		s.PresetDifficulty(randomSelectionCommand)
+		s.PresetTheme(theme.Default)
+	}
+
+	query, err := s.chooseQuery(cts, ws.Tags(), randomSelectionCommand)
 	if err != nil {
-		return fmt.Errorf("can`t choose conditions: %w", err)
+		return fmt.Errorf("can`t choose category: %w", err)
 	}
 
-	if category == randomSelectionCommand {
-		category = getRandomCategory(cts)
+	difficulty, err := s.chooseDifficulty(dfs, randomSelectionCommand)
+	if err != nil {
+		return fmt.Errorf("can`t choose difficulty: %w", err)
+	}
+
+	themeName, err := s.chooseTheme(ths, randomSelectionCommand)
+	if err != nil {
+		return fmt.Errorf("can`t choose theme: %w", err)
+	}
+
+	var category string
+
+	switch {
+	case s.presetWord != nil, query.HasFilters():
+	case len(query.Categories) == 1:
+		category = query.Categories[0]
+	default:
+		category, err = getRandomCategory(s.random, cts)
+		if err != nil {
+			return fmt.Errorf("can`t choose random category: %w", err)
+		}
 	}
 
 	if difficulty == randomSelectionCommand {
-		difficulty = getRandomDifficulty(dfs)
+		difficulty, err = getRandomDifficulty(s.random, dfs)
+		if err != nil {
+			return fmt.Errorf("can`t choose random difficulty: %w", err)
+		}
 	}
 
-	wordData := ws.GetRandomWordData(category, difficulty)
+	if themeName == randomSelectionCommand {
+		themeName, err = getRandomCondition(s.random, ths)
+		if err != nil {
+			return fmt.Errorf("can`t choose random theme: %w", err)
+		}
+	}
 
-	s.maxAttmeps = dfs[difficulty]
-	s.answer = answer.New(wordData, category, difficulty)
-	s.status = status.New(wordData.Word)
-	s.storyboard = storyboard.CreateStoryboard(sfm, s.maxAttmeps)
+	entry, err := s.chooseWord(ws, query, category, difficulty)
+	if err != nil {
+		return fmt.Errorf("can`t get word: %w", err)
+	}
+
+	frameIndexes, err := storyboard.GenerateFrameIndexes(s.random, len(ths[themeName].Frames["process"]), dfs[difficulty])
+	if err != nil {
+		return fmt.Errorf("can`t generate storyboard: %w", err)
+	}
+
+	return s.setup(entry.WordData, entry.Category, difficulty, themeName, ths[themeName], frameIndexes)
+}
+
+// configureReplay конфигурирует игровую сессию по записи игры. Запись должна соответствовать кадрам темы.
+func (s *Session) configureReplay(ths theme.Themes) error {
+	rp := s.replay
+
+	th, ok := ths[rp.Theme]
+	if !ok {
+		return &theme.NameError{Name: rp.Theme, Err: theme.ErrUnknownTheme}
+	}
+
+	if rp.Attempts < 1 || len(rp.FrameIndexes) != rp.Attempts {
+		err := fmt.Errorf("%w: %d frame indexes for %d attempts", ErrReplayMismatch, len(rp.FrameIndexes), rp.Attempts)
+		return &theme.NameError{Name: rp.Theme, Err: err}
+	}
+
+	for _, frameIndex := range rp.FrameIndexes {
+		if frameIndex < 0 || frameIndex >= len(th.Frames["process"]) {
+			err := fmt.Errorf("%w: frame index %d is out of range", ErrReplayMismatch, frameIndex)
+			return &theme.NameError{Name: rp.Theme, Err: err}
+		}
+	}
+
+	wd := words.WordData{Word: rp.Word, Hints: rp.Hints, Explanation: rp.Explanation, Source: rp.Source}
+
+	return s.setup(wd, rp.Category, rp.Difficulty, rp.Theme, th, rp.FrameIndexes)
+}
+
+// setup подготавливает сессию к игре с выбранными словом, условиями, темой и номерами кадров раскадровки.
+// Количество попыток равно количеству номеров кадров.
+func (s *Session) setup(
+	wd words.WordData,
+	category, difficulty, themeName string,
+	th theme.Theme,
+	frameIndexes []int,
+) error {
+	game, err := hangman.NewGame(hangman.Options{Word: wd.Word, Hints: wd.Hints, Attempts: len(frameIndexes)})
+	if err != nil {
+		return fmt.Errorf("can`t create game: %w", err)
+	}
+
+	s.game = game
+	s.maxAttmeps = len(frameIndexes)
+	s.answer = answer.New(wd, category, difficulty)
+	s.converter = layout.NewConverter(s.layouts, wd.Word)
+	s.theme = th
+	s.themeName = themeName
+	s.frameIndexes = frameIndexes
+	s.storyboard = storyboard.Build(th.Frames, frameIndexes)
+	s.console.SetColors(th.Colors)
+
+	s.events.Publish(events.ConditionsChosen{
+		Category:   category,
+		Difficulty: difficulty,
+		Theme:      themeName,
+		WordLength: len(s.game.State().DisplayedWord),
+		Attempts:   s.maxAttmeps,
+	})
 
 	return nil
 }
 
-// Play запускает проигрывание раунда.
-func (s *Session) playRound(attempts int) (int, error) {
-	frame := s.storyboard["process"][s.maxAttmeps-attempts]
+// chooseQuery возвращает заранее заданный фильтр слов, фильтр по заранее заданной категории
+// или запрашивает фильтр у пользователя. Фильтр без категорий и других условий означает случайную категорию.
+// Если слово задано заранее, фильтр не нужен и возвращается пустым.
+func (s *Session) chooseQuery(cts conditions.Categories, tags []string, randomSelectionCommand string) (words.Query, error) {
+	var query words.Query
+
+	switch {
+	case s.presetWord != nil:
+		return query, nil
+	case s.presetQuery != nil:
+		query = *s.presetQuery
+	case s.presetCategory != nil && *s.presetCategory != randomSelectionCommand:
+		query.Categories = []string{*s.presetCategory}
+	case s.presetCategory != nil:
+		return query, nil
+	default:
+		q, excludeSeen, err := s.console.ChooseWords(cts, tags, randomSelectionCommand)
+		if err != nil {
+			return query, err
+		}
 
-	s.console.DisplaySessionStatus(
-		s.answer.Category,
-		s.answer.Difficulty,
-		frame,
-		s.status.DisplayedWord,
-		attempts,
-		s.lettersUsed,
-	)
+		if excludeSeen {
+			q.Exclude = s.seen
+		}
 
-	var (
-		letter rune
-		err    error
-	)
+		return q, nil
+	}
+
+	for _, category := range query.Categories {
+		if _, ok := cts[category]; !ok {
+			return query, &words.ConditionError{Category: category, Err: words.ErrUnknownCategory}
+		}
+	}
+
+	return query, nil
+}
+
+// chooseDifficulty возвращает заранее заданный уровень сложности или запрашивает его у пользователя.
+func (s *Session) chooseDifficulty(dfs conditions.Difficulties, randomSelectionCommand string) (string, error) {
+	if s.presetDifficulty == nil {
+		return s.console.ChooseDifficulty(dfs, randomSelectionCommand)
+	}
+
+	difficulty := *s.presetDifficulty
+	if _, ok := dfs[difficulty]; !ok && difficulty != randomSelectionCommand {
+		return "", &words.ConditionError{Difficulty: difficulty, Err: words.ErrUnknownDifficulty}
+	}
+
+	return difficulty, nil
+}
+
+// chooseTheme возвращает заранее заданную тему, единственную доступную тему или запрашивает её у пользователя.
+func (s *Session) chooseTheme(ths theme.Themes, randomSelectionCommand string) (string, error) {
+	if s.presetTheme == nil && len(ths) == 1 {
+		for name := range ths {
+			return name, nil
+		}
+	}
+
+	if s.presetTheme == nil {
+		return s.console.ChooseTheme(ths, randomSelectionCommand)
+	}
+
+	name := *s.presetTheme
+	if _, ok := ths[name]; !ok && name != randomSelectionCommand {
+		return "", &theme.NameError{Name: name, Err: theme.ErrUnknownTheme}
+	}
+
+	return name, nil
+}
+
+// playRound запускает проигрывание раунда. Строка из нескольких символов называет слово целиком, а символ - букву
+// или команду. Буквы и слова, которые движок не принимает, запрашиваются снова без расхода попытки.
+// После ошибки проигрывается переход к следующему кадру раскадровки.
+func (s *Session) playRound(ctx context.Context, msTransitionDelay int) error {
+	state := s.game.State()
+
+	s.displayStatus()
+
+	var result hangman.Result
 
 	for {
-		letter, err = s.console.Enter()
+		input, err := s.console.Enter()
+		if err != nil {
+			return fmt.Errorf("can`t enter letter: %w", err)
+		}
+
+		letter, _ := utf8.DecodeRuneInString(input)
+
+		var ok bool
+
+		switch {
+		case utf8.RuneCountInString(input) > 1:
+			result, ok, err = s.guessWord(input)
+		case letter == hintCommand:
+			hint, number := s.game.NextHint()
+			if number != 0 {
+				s.events.Publish(events.HintUsed{Hint: hint, Number: number})
+			}
+
+			s.console.DisplayHint(hint, number, state.HintsTotal)
+		case letter == undoCommand && s.practice && len(s.history) == 0:
+			s.console.DisplayNothingToUndo()
+		case letter == undoCommand && s.practice:
+			s.undo()
+			return nil
+		default:
+			result, ok, err = s.guessLetter(letter)
+		}
+
 		if err != nil {
-			return 0, fmt.Errorf("can`t enter letter: %w", err)
+			return err
 		}
 
-		if letter == '?' {
-			s.console.DisplayHint(s.answer.Hint)
-		} else if _, ok := s.lettersUsed[letter]; !ok {
+		if ok {
 			break
 		}
 	}
 
-	positions := s.answer.GetLetterPositions(letter)
-	s.status.ShowLetters(letter, positions)
+	if result.Word != "" {
+		s.events.Publish(events.WordGuessed{Word: result.Word, Hit: result.Hit})
+	} else {
+		s.events.Publish(events.LetterGuessed{
+			Letter:    result.Letter,
+			Hit:       result.Hit,
+			Positions: result.Positions,
+		})
+	}
 
-	if len(positions) == 0 {
-		attempts--
+	if !result.Hit {
+		s.events.Publish(events.AttemptLost{AttemptsLeft: result.AttemptsLeft})
+		s.console.PlayTransition(ctx, s.storyboard.Animation("process", s.maxAttmeps-result.AttemptsLeft), msTransitionDelay)
 	}
 
-	s.updateLettersUsed(letter)
+	s.guesses = append(s.guesses, result.Hit)
 
-	return attempts, nil
+	return nil
 }
 
-// updateLettersUsed обновляет список использованных букв, внося переданную букву.
-func (s *Session) updateLettersUsed(letter rune) {
-	s.lettersUsed[letter] = struct{}{}
+// guessLetter называет букву, исправив раскладку клавиатуры. Возвращаемый признак равен false,
+// если буква не принята и попытка не расходуется.
+func (s *Session) guessLetter(letter rune) (hangman.Result, bool, error) {
+	input, ok, err := s.correctLayout(string(letter))
+	if err != nil {
+		return hangman.Result{}, false, fmt.Errorf("can`t correct layout: %w", err)
+	}
+
+	if !ok {
+		return hangman.Result{}, false, nil
+	}
+
+	letter, _ = utf8.DecodeRuneInString(input)
+
+	return s.guess(func() (hangman.Result, error) { return s.game.Guess(letter) })
+}
+
+// guessWord называет слово целиком, исправив раскладку клавиатуры. Если задан список слов языка, неверное слово,
+// которого в нём нет, не принимается, и попытка не расходуется. Возвращаемый признак равен false, если слово не принято.
+func (s *Session) guessWord(word string) (hangman.Result, bool, error) {
+	word, ok, err := s.correctLayout(strings.ToLower(word))
+	if err != nil {
+		return hangman.Result{}, false, fmt.Errorf("can`t correct layout: %w", err)
+	}
+
+	if !ok {
+		return hangman.Result{}, false, nil
+	}
+
+	if s.isUnknownWord(word) {
+		if !slices.Contains(s.unknownWords, word) {
+			s.unknownWords = append(s.unknownWords, word)
+		}
+
+		s.console.DisplayError(&dictionary.WordError{Word: word, Err: dictionary.ErrUnknownWord})
+
+		return hangman.Result{}, false, nil
+	}
+
+	return s.guess(func() (hangman.Result, error) { return s.game.GuessWord(word) })
+}
+
+// isUnknownWord возвращает true, если слово не было принято в записи игры или это неверное слово,
+// которого нет в списке слов языка, иначе false.
+func (s *Session) isUnknownWord(word string) bool {
+	if s.replay != nil && slices.Contains(s.replay.UnknownWords, word) {
+		return true
+	}
+
+	return s.dictionary != nil && word != s.game.Word() && !s.dictionary.Contains(word)
+}
+
+// guess делает попытку attempt, в тренировочной игре сохраняя перед ней снимок хода в историю.
+// Недопустимая или повторная буква или слово выводятся как ошибка, и попытка не принимается.
+// Возвращаемый признак равен false, если попытка не принята.
+func (s *Session) guess(attempt func() (hangman.Result, error)) (hangman.Result, bool, error) {
+	var snap snapshot
+	if s.practice {
+		snap = s.takeSnapshot()
+	}
+
+	result, err := attempt()
+	if errors.Is(err, hangman.ErrInvalidLetter) || errors.Is(err, hangman.ErrInvalidWord) ||
+		errors.Is(err, hangman.ErrAlreadyGuessed) {
+		s.console.DisplayError(err)
+		return result, false, nil
+	}
+
+	if err != nil {
+		return result, false, fmt.Errorf("can`t guess: %w", err)
+	}
+
+	if s.practice {
+		s.history = append(s.history, snap)
+	}
+
+	return result, true, nil
+}
+
+// displayStatus выводит статус сессии с кадром раскадровки, соответствующим количеству израсходованных попыток.
+// После последней ошибки выводится последний кадр процесса.
+func (s *Session) displayStatus() {
+	state := s.game.State()
+	frameIndex := min(state.MaxAttempts-state.AttemptsLeft, len(s.storyboard["process"])-1)
+
+	s.console.DisplaySessionStatus(
+		s.answer.Category,
+		s.answer.Difficulty,
+		s.storyboard["process"][frameIndex],
+		state.DisplayedWord,
+		state.AttemptsLeft,
+		letterSet(state.LettersUsed),
+	)
+}
+
+// offerUndo в тренировочной игре выводит итог завершившей игру попытки и предлагает её отменить.
+// При согласии ход сессии восстанавливается из последнего снимка истории, и игра продолжается.
+func (s *Session) offerUndo() error {
+	if !s.practice || len(s.history) == 0 {
+		return nil
+	}
+
+	s.displayStatus()
+
+	ok, err := s.console.ConfirmUndo()
+	if err != nil {
+		return fmt.Errorf("can`t confirm undo: %w", err)
+	}
+
+	if ok {
+		s.undo()
+	}
+
+	return nil
+}
+
+// undo отменяет последнюю попытку, восстанавливая ход сессии из последнего снимка истории.
+func (s *Session) undo() {
+	last := s.history[len(s.history)-1]
+	s.history = s.history[:len(s.history)-1]
+
+	s.restore(last)
+	s.events.Publish(events.GuessUndone{AttemptsLeft: s.game.State().AttemptsLeft})
+}
+
+// correctLayout возвращает ввод, в котором символы, набранные в другой раскладке, заменены буквами алфавита слова
+// на тех же клавишах. Так исправляются и буква, и слово целиком. В режиме layout.ModeAsk замена предлагается
+// пользователю, а при отказе ввод не принимается: с символами не из алфавита слова попытка всё равно неверна.
+// Возвращаемый признак равен false, если ввод не принят и попытка не расходуется.
+func (s *Session) correctLayout(input string) (string, bool, error) {
+	if s.layoutMode == layout.ModeOff {
+		return input, true, nil
+	}
+
+	converted, ok := s.converter.ConvertWord(input)
+	if !ok {
+		return input, true, nil
+	}
+
+	if s.layoutMode == layout.ModeConvert {
+		s.console.DisplayLayoutConverted(input, converted)
+		return converted, true, nil
+	}
+
+	confirmed, err := s.console.ConfirmLayout(input, converted)
+	if err != nil {
+		return input, false, fmt.Errorf("can`t confirm layout: %w", err)
+	}
+
+	return converted, confirmed, nil
+}
+
+// letterSet возвращает множество переданных букв.
+func letterSet(letters []rune) map[rune]struct{} {
+	set := make(map[rune]struct{}, len(letters))
+	for _, letter := range letters {
+		set[letter] = struct{}{}
+	}
+
+	return set
+}
+
+// chooseWord возвращает заранее заданное слово без категории или выбирает слово уровня сложности difficulty,
+// подходящее под фильтр, или слово категории category, если фильтр не задан.
+// Если подходящих под фильтр слов нет, возвращает words.ErrNoMatches.
+func (s *Session) chooseWord(ws words.Words, q words.Query, category, difficulty string) (words.Entry, error) {
+	if s.presetWord != nil {
+		return words.Entry{WordData: words.WordData{Word: *s.presetWord}}, nil
+	}
+
+	var (
+		entries []words.Entry
+		err     error
+	)
+
+	if q.HasFilters() {
+		entries = ws.Select(q, difficulty)
+		if len(entries) == 0 {
+			return words.Entry{}, &words.ConditionError{Difficulty: difficulty, Err: words.ErrNoMatches}
+		}
+	} else {
+		entries, err = ws.Entries(category, difficulty)
+		if err != nil {
+			return words.Entry{}, err
+		}
+	}
+
+	selector := s.selector
+	if s.challenge != nil {
+		selector = words.Uniform{}
+	}
+
+	return selector.Choose(s.random, entries)
 }
 
 // getRandomCategory возвращает случайную категорию.
-func getRandomCategory(cts conditions.Categories) string {
-	return getRandomCondition(cts)
+func getRandomCategory(rnd random.Source, cts conditions.Categories) (string, error) {
+	return getRandomCondition(rnd, cts)
 }
 
 // getRandomDifficulty возвращает случайный уровень сложности.
-func getRandomDifficulty(dfs conditions.Difficulties) string {
-	return getRandomCondition(dfs)
+func getRandomDifficulty(rnd random.Source, dfs conditions.Difficulties) (string, error) {
+	return getRandomCondition(rnd, dfs)
 }
 
-// getRandomDifficulty возвращает случайное условие.
-func getRandomCondition[T any](conds map[string]T) string {
-	var condition string
+// getRandomCondition возвращает случайное условие или ErrNoConditions, если выбирать не из чего.
+// Условия упорядочиваются, чтобы выбор при одинаковом зерне генератора не зависел от порядка обхода словаря.
+func getRandomCondition[T any](rnd random.Source, conds map[string]T) (string, error) {
+	keys := make([]string, 0, len(conds))
+	for cond := range conds {
+		keys = append(keys, cond)
+	}
 
-	randIndex := random.RandInt(len(conds))
-	i := 0
+	if len(keys) == 0 {
+		return "", ErrNoConditions
+	}
 
-	for cond := range conds {
-		if i == randIndex {
-			condition = cond
-			break
-		}
+	sort.Strings(keys)
 
-		i++
+	i, err := rnd.Int(len(keys))
+	if err != nil {
+		return "", err
 	}
 
-	return condition
+	return keys[i], nil
 }
@@ -0,0 +1,438 @@
+package session_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/conditions"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/dictionary"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/events"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/frames"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/layout"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/random"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/replay"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/session"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/theme"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/words"
+	"github.com/es-debug/backend-academy-2024-go-template/pkg/hangman"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeConsole подаёт сессии заданные буквы и слова и ответы на предложения отменить попытку и исправить раскладку,
+// записывает выведенные статусы сессии строками "<слово> <попытки> <использованные буквы>", выведенные ошибки
+// и исправления раскладки строками "<набранное> -> <исправленное>". Без ответов раскладка исправляется.
+type fakeConsole struct {
+	inputs        []string
+	undos         []bool
+	layoutAnswers []bool
+	statuses      []string
+	errs          []error
+	layouts       []string
+	nothingToUndo int
+}
+
+func (fc *fakeConsole) ChooseWords(conditions.Categories, []string, string) (words.Query, bool, error) {
+	return words.Query{}, false, nil
+}
+
+func (fc *fakeConsole) ChooseDifficulty(conditions.Difficulties, string) (string, error) {
+	return "", nil
+}
+
+func (fc *fakeConsole) ChooseTheme(theme.Themes, string) (string, error) { return "", nil }
+
+func (fc *fakeConsole) SetColors(map[string]string) {}
+
+func (fc *fakeConsole) Enter() (string, error) {
+	if len(fc.inputs) == 0 {
+		return "", io.EOF
+	}
+
+	input := fc.inputs[0]
+	fc.inputs = fc.inputs[1:]
+
+	return input, nil
+}
+
+func (fc *fakeConsole) ConfirmLayout(typed, converted string) (bool, error) {
+	fc.layouts = append(fc.layouts, typed+" -> "+converted)
+
+	if len(fc.layoutAnswers) == 0 {
+		return true, nil
+	}
+
+	ok := fc.layoutAnswers[0]
+	fc.layoutAnswers = fc.layoutAnswers[1:]
+
+	return ok, nil
+}
+
+func (fc *fakeConsole) ConfirmUndo() (bool, error) {
+	if len(fc.undos) == 0 {
+		return false, io.EOF
+	}
+
+	ok := fc.undos[0]
+	fc.undos = fc.undos[1:]
+
+	return ok, nil
+}
+
+func (fc *fakeConsole) DisplayLayoutConverted(typed, converted string) {
+	fc.layouts = append(fc.layouts, typed+" -> "+converted)
+}
+
+func (fc *fakeConsole) DisplayHint(string, int, int) {}
+
+func (fc *fakeConsole) DisplayNothingToUndo() { fc.nothingToUndo++ }
+
+func (fc *fakeConsole) DisplayError(err error) { fc.errs = append(fc.errs, err) }
+
+func (fc *fakeConsole) DisplaySessionStatus(
+	_, _ string,
+	_ frames.Frame,
+	displayedWord []rune,
+	attempts int,
+	lettersUsed map[rune]struct{},
+) {
+	used := make([]rune, 0, len(lettersUsed))
+	for letter := range lettersUsed {
+		used = append(used, letter)
+	}
+
+	slices.Sort(used)
+
+	fc.statuses = append(fc.statuses, fmt.Sprintf("%s %d %s", string(displayedWord), attempts, string(used)))
+}
+
+func (fc *fakeConsole) PlayAnimation(context.Context, string, []frames.Frame, int) {}
+
+func (fc *fakeConsole) PlayTransition(context.Context, []frames.Frame, int) {}
+
+func (fc *fakeConsole) DisplayMessage(string) {}
+
+func (fc *fakeConsole) DisplayExplanation(string, string) {}
+
+func (fc *fakeConsole) DisplaySummary(string) {}
+
+func (fc *fakeConsole) DisplaySeed(uint64) {}
+
+// testThemes - тема с двумя кадрами процесса для игр с двумя попытками.
+var testThemes = theme.Themes{
+	"тест": {Frames: frames.StageFramesMap{
+		"process": {{Lines: []string{"0"}}, {Lines: []string{"1"}}},
+		"victory": {{Lines: []string{"победа"}}},
+		"defeat":  {{Lines: []string{"поражение"}}},
+	}},
+}
+
+func TestPlayUndo(t *testing.T) {
+	rp := replay.Replay{
+		Word:         "кот",
+		Category:     "животные",
+		Difficulty:   "лёгкая",
+		Theme:        "тест",
+		Attempts:     2,
+		FrameIndexes: []int{0, 1},
+		Practice:     true,
+	}
+
+	tests := []struct {
+		name          string
+		letters       string
+		undos         []bool
+		won           bool
+		statuses      []string
+		nothingToUndo int
+	}{
+		{
+			name:    "undo restores letters and attempts",
+			letters: "кх<от",
+			undos:   []bool{false},
+			won:     true,
+			statuses: []string{
+				"___ 2 ", "к__ 2 к", "к__ 1 кх", "к__ 2 к", "ко_ 2 ко", "кот 2 кот",
+			},
+		},
+		{
+			name:    "consecutive undos restore earlier snapshots",
+			letters: "кх<<от<тк",
+			undos:   []bool{false},
+			won:     true,
+			statuses: []string{
+				"___ 2 ", "к__ 2 к", "к__ 1 кх", "к__ 2 к", "___ 2 ", "_о_ 2 о", "_от 2 от", "_о_ 2 о", "_от 2 от",
+				"кот 2 кот",
+			},
+		},
+		{
+			name:          "nothing to undo",
+			letters:       "<кот",
+			undos:         []bool{false},
+			won:           true,
+			statuses:      []string{"___ 2 ", "к__ 2 к", "ко_ 2 ко", "кот 2 кот"},
+			nothingToUndo: 1,
+		},
+		{
+			name:     "undo final guess",
+			letters:  "хшкот",
+			undos:    []bool{true, false},
+			won:      true,
+			statuses: []string{"___ 2 ", "___ 1 х", "___ 0 хш", "___ 1 х", "к__ 1 кх", "ко_ 1 кох", "кот 1 котх"},
+		},
+		{
+			name:     "keep final guess",
+			letters:  "хш",
+			undos:    []bool{false},
+			won:      false,
+			statuses: []string{"___ 2 ", "___ 1 х", "___ 0 хш"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fc := &fakeConsole{inputs: strings.Split(tt.letters, ""), undos: tt.undos}
+
+			s := session.New(fc, random.New(0))
+			s.PresetReplay(rp)
+
+			err := s.Play(context.Background(), nil, nil, "", 0, 0, testThemes)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.won, s.IsWon())
+			assert.Equal(t, tt.statuses, fc.statuses)
+			assert.Equal(t, tt.nothingToUndo, fc.nothingToUndo)
+			assert.Empty(t, fc.inputs)
+			assert.Empty(t, fc.undos)
+		})
+	}
+}
+
+func TestPlayHintAndUndoEvents(t *testing.T) {
+	rp := replay.Replay{
+		Word:         "кот",
+		Category:     "животные",
+		Difficulty:   "лёгкая",
+		Theme:        "тест",
+		Attempts:     2,
+		FrameIndexes: []int{0, 1},
+		Practice:     true,
+	}
+
+	tests := []struct {
+		name     string
+		hints    []string
+		letters  string
+		undos    []bool
+		expected []events.Event
+	}{
+		{
+			name:    "no event without hints",
+			letters: "?кот",
+			undos:   []bool{false},
+		},
+		{
+			name:     "revealed hints",
+			hints:    []string{"Мяукает", "Ловит мышей"},
+			letters:  "???кот",
+			undos:    []bool{false},
+			expected: []events.Event{
+				events.HintUsed{Hint: "Мяукает", Number: 1},
+				events.HintUsed{Hint: "Ловит мышей", Number: 2},
+				events.HintUsed{Hint: "Ловит мышей", Number: 2},
+			},
+		},
+		{
+			name:     "undo",
+			letters:  "кх<от",
+			undos:    []bool{false},
+			expected: []events.Event{events.GuessUndone{AttemptsLeft: 2}},
+		},
+		{
+			name:     "undo final guess",
+			letters:  "хшкот",
+			undos:    []bool{true, false},
+			expected: []events.Event{events.GuessUndone{AttemptsLeft: 1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fc := &fakeConsole{inputs: strings.Split(tt.letters, ""), undos: tt.undos}
+
+			r := rp
+			r.Hints = tt.hints
+
+			s := session.New(fc, random.New(0))
+			s.PresetReplay(r)
+
+			var published []events.Event
+
+			err := s.Events().Subscribe(func(e events.Event) {
+				switch e.(type) {
+				case events.HintUsed, events.GuessUndone:
+					published = append(published, e)
+				}
+			})
+			assert.NoError(t, err)
+
+			err = s.Play(context.Background(), nil, nil, "", 0, 0, testThemes)
+			assert.NoError(t, err)
+
+			assert.True(t, s.IsWon())
+			assert.Equal(t, tt.expected, published)
+		})
+	}
+}
+
+func TestPlayWordGuess(t *testing.T) {
+	ws := words.Words{"животные": {"лёгкая": {{Word: "Кот"}}}}
+	dfs := conditions.Difficulties{"лёгкая": 2}
+
+	tests := []struct {
+		name       string
+		word       string
+		dictionary *dictionary.Dictionary
+		inputs     []string
+		won        bool
+		statuses   []string
+		errs       []error
+	}{
+		{
+			name:     "wrong word costs an attempt",
+			inputs:   []string{"кит", "кОт"},
+			won:      true,
+			statuses: []string{"___ 2 ", "___ 1 "},
+		},
+		{
+			name:     "repeated word is rejected",
+			inputs:   []string{"кит", "кит", "кат"},
+			won:      false,
+			statuses: []string{"___ 2 ", "___ 1 "},
+			errs:     []error{hangman.ErrAlreadyGuessed},
+		},
+		{
+			name:       "word missing from the dictionary is rejected",
+			dictionary: dictionary.New([]string{"кит", "кот"}),
+			inputs:     []string{"кат", "к", "кот"},
+			won:        true,
+			statuses:   []string{"___ 2 ", "к__ 2 к"},
+			errs:       []error{dictionary.ErrUnknownWord},
+		},
+		{
+			name:       "custom word",
+			word:       "Шар-пей",
+			dictionary: dictionary.New([]string{"кот"}),
+			inputs:     []string{"шар-пей"},
+			won:        true,
+			statuses:   []string{"___-___ 2 "},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fc := &fakeConsole{inputs: tt.inputs}
+
+			s := session.New(fc, random.New(0))
+			s.PresetCategory("животные")
+			s.PresetDifficulty("лёгкая")
+			s.SetDictionary(tt.dictionary)
+
+			if tt.word != "" {
+				s.PresetWord(tt.word)
+			}
+
+			err := s.Play(context.Background(), ws, dfs, "", 0, 0, testThemes)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.won, s.IsWon())
+			assert.Equal(t, tt.statuses, fc.statuses)
+			assert.Len(t, fc.errs, len(tt.errs))
+
+			for i, expected := range tt.errs {
+				assert.ErrorIs(t, fc.errs[i], expected)
+			}
+		})
+	}
+}
+
+func TestPlayWordLayout(t *testing.T) {
+	ws := words.Words{"животные": {"лёгкая": {{Word: "Кот"}}}}
+	dfs := conditions.Difficulties{"лёгкая": 2}
+	lts := layout.Layouts{
+		"qwerty": "`qwertyuiop[]asdfghjkl;'zxcvbnm,.",
+		"йцукен": "ёйцукенгшщзхъфывапролджэячсмитьбю",
+	}
+
+	tests := []struct {
+		name     string
+		mode     string
+		inputs   []string
+		answers  []bool
+		won      bool
+		statuses []string
+		layouts  []string
+	}{
+		{
+			name:     "word converted",
+			mode:     layout.ModeConvert,
+			inputs:   []string{"RJN"},
+			won:      true,
+			statuses: []string{"___ 2 "},
+			layouts:  []string{"rjn -> кот"},
+		},
+		{
+			name:     "mixed word converted after confirmation",
+			mode:     layout.ModeAsk,
+			inputs:   []string{"кjn"},
+			won:      true,
+			statuses: []string{"___ 2 "},
+			layouts:  []string{"кjn -> кот"},
+		},
+		{
+			name:     "refused conversion is not accepted",
+			mode:     layout.ModeAsk,
+			inputs:   []string{"rbn", "rjn"},
+			answers:  []bool{false, true},
+			won:      true,
+			statuses: []string{"___ 2 "},
+			layouts:  []string{"rbn -> кит", "rjn -> кот"},
+		},
+		{
+			name:     "wrong converted word costs an attempt",
+			mode:     layout.ModeConvert,
+			inputs:   []string{"rbn", "к", "кот"},
+			won:      true,
+			statuses: []string{"___ 2 ", "___ 1 ", "к__ 1 к"},
+			layouts:  []string{"rbn -> кит"},
+		},
+		{
+			name:     "conversion is off",
+			mode:     layout.ModeOff,
+			inputs:   []string{"rjn", "кот"},
+			won:      true,
+			statuses: []string{"___ 2 ", "___ 1 "},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fc := &fakeConsole{inputs: tt.inputs, layoutAnswers: tt.answers}
+
+			s := session.New(fc, random.New(0))
+			s.PresetCategory("животные")
+			s.PresetDifficulty("лёгкая")
+			s.SetKeyboardLayouts(tt.mode, lts)
+
+			err := s.Play(context.Background(), ws, dfs, "", 0, 0, testThemes)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.won, s.IsWon())
+			assert.Equal(t, tt.statuses, fc.statuses)
+			assert.Equal(t, tt.layouts, fc.layouts)
+		})
+	}
+}
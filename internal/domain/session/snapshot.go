@@ -0,0 +1,25 @@
+package session
+
+import (
+	"github.com/es-debug/backend-academy-2024-go-template/pkg/hangman"
+)
+
+// snapshot хранит неизменяемый снимок хода сессии: копию игры движка и количество сделанных попыток угадать букву.
+type snapshot struct {
+	game    *hangman.Game
+	guesses int
+}
+
+// takeSnapshot возвращает снимок текущего хода сессии.
+func (s *Session) takeSnapshot() snapshot {
+	return snapshot{
+		game:    s.game.Clone(),
+		guesses: len(s.guesses),
+	}
+}
+
+// restore восстанавливает ход сессии из снимка. Игра снимка копируется, чтобы снимок оставался неизменным.
+func (s *Session) restore(snap snapshot) {
+	s.game = snap.game.Clone()
+	s.guesses = s.guesses[:snap.guesses]
+}
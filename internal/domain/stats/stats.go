@@ -0,0 +1,61 @@
+package stats
+
+// Counter хранит количество сыгранных и выигранных игр.
+type Counter struct {
+	Played int
+	Won    int
+}
+
+// Stats хранит общую статистику игр, текущую и лучшую серии побед и статистику по уровням сложности.
+type Stats struct {
+	Counter
+	CurrentStreak int
+	BestStreak    int
+	Difficulties  map[string]Counter
+}
+
+// New возвращает инициализированную структуру Stats без сыгранных игр.
+func New() Stats {
+	return Stats{
+		Difficulties: make(map[string]Counter),
+	}
+}
+
+// Add учитывает в статистике результат игры на указанном уровне сложности.
+func (st *Stats) Add(difficulty string, won bool) {
+	if st.Difficulties == nil {
+		st.Difficulties = make(map[string]Counter)
+	}
+
+	counter := st.Difficulties[difficulty]
+	counter.add(won)
+	st.Difficulties[difficulty] = counter
+
+	st.Counter.add(won)
+
+	if !won {
+		st.CurrentStreak = 0
+		return
+	}
+
+	st.CurrentStreak++
+	st.BestStreak = max(st.BestStreak, st.CurrentStreak)
+}
+
+// WinRate возвращает долю выигранных игр в процентах. Если игр не было, возвращает 0.
+func (c Counter) WinRate() float64 {
+	if c.Played == 0 {
+		return 0
+	}
+
+	return float64(c.Won) / float64(c.Played) * 100
+}
+
+// add учитывает результат одной игры.
+func (c *Counter) add(won bool) {
+	c.Played++
+
+	if won {
+		c.Won++
+	}
+}
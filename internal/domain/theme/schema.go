@@ -0,0 +1,70 @@
+package theme
+
+import (
+	"slices"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/frames"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/schema"
+)
+
+// Validate проверяет соответствие декодированного описания темы схеме и возвращает все найденные нарушения.
+// Кадры темы хранятся в отдельном файле и проверяются frames.Validate.
+func Validate(raw any, source string) schema.Problems {
+	var problems schema.Problems
+
+	obj, ok := schema.Object(&problems, source, schema.Root, raw)
+	if !ok {
+		return problems
+	}
+
+	schema.Fields(&problems, source, schema.Root, obj, []string{"name"}, []string{"colors", "messages"})
+
+	if name, ok := obj["name"]; ok {
+		if s, ok := schema.String(&problems, source, "$.name", name); ok && s == "" {
+			problems.Add(source, "$.name", "name must not be empty")
+		}
+	}
+
+	if colors, ok := obj["colors"]; ok {
+		validateColors(&problems, source, colors)
+	}
+
+	if messages, ok := obj["messages"]; ok {
+		validateMessages(&problems, source, messages)
+	}
+
+	return problems
+}
+
+// validateColors проверяет, что цвета заданы для известных этапов и известными названиями.
+func validateColors(problems *schema.Problems, source string, raw any) {
+	colors, ok := schema.Object(problems, source, "$.colors", raw)
+	if !ok {
+		return
+	}
+
+	schema.Fields(problems, source, "$.colors", colors, nil, frames.Stages)
+
+	for _, stage := range schema.SortedKeys(colors) {
+		path := schema.Key("$.colors", stage)
+
+		color, ok := schema.String(problems, source, path, colors[stage])
+		if ok && !slices.Contains(ColorNames, color) {
+			problems.Add(source, path, "unknown color %q, expected one of %v", color, ColorNames)
+		}
+	}
+}
+
+// validateMessages проверяет, что сообщения заданы строками для победы и поражения.
+func validateMessages(problems *schema.Problems, source string, raw any) {
+	messages, ok := schema.Object(problems, source, "$.messages", raw)
+	if !ok {
+		return
+	}
+
+	schema.Fields(problems, source, "$.messages", messages, nil, []string{"victory", "defeat"})
+
+	for _, key := range schema.SortedKeys(messages) {
+		schema.String(problems, source, schema.Key("$.messages", key), messages[key])
+	}
+}
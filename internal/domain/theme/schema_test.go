@@ -0,0 +1,54 @@
+package theme_test
+
+import (
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/theme"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	tt := []struct {
+		raw   any
+		paths []string
+	}{
+		{
+			raw:   map[string]any{"name": "снеговик"},
+			paths: nil,
+		},
+		{
+			raw: map[string]any{
+				"name":     "снеговик",
+				"colors":   map[string]any{"process": "white", "victory": "cyan"},
+				"messages": map[string]any{"defeat": "Снеговик растаял..."},
+			},
+			paths: nil,
+		},
+		{
+			raw: map[string]any{
+				"name":     "",
+				"colors":   map[string]any{"process": "purple", "finale": "red"},
+				"messages": map[string]any{"victory": 1.0},
+				"frames":   "frames.frames",
+			},
+			paths: []string{
+				"$.frames",
+				"$.name",
+				"$.colors.finale",
+				"$.colors.process",
+				"$.messages.victory",
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		problems := theme.Validate(tc.raw, "theme.json")
+
+		var paths []string
+		for _, p := range problems {
+			paths = append(paths, p.Path)
+		}
+
+		assert.Equal(t, tc.paths, paths)
+	}
+}
@@ -0,0 +1,59 @@
+package theme
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/frames"
+)
+
+// Default - название встроенной темы, кадры которой берутся из файла кадров конфига.
+const Default = "виселица"
+
+// Messages хранит необязательные сообщения темы, выводимые после победы и поражения.
+type Messages struct {
+	Victory string
+	Defeat  string
+}
+
+// Theme хранит название темы, цвета кадров каждого этапа, сообщения и набор кадров.
+type Theme struct {
+	Name     string
+	Colors   map[string]string
+	Messages Messages
+	Frames   frames.StageFramesMap
+}
+
+// Themes - словарь, сопоставляющий названиям тем сами темы.
+type Themes map[string]Theme
+
+// ErrUnknownTheme - темы с таким названием нет.
+var ErrUnknownTheme = errors.New("unknown theme")
+
+// NameError - ошибка, связанная с темой оформления Name, например ErrUnknownTheme.
+type NameError struct {
+	Name string
+	Err  error
+}
+
+// Error возвращает описание ошибки.
+func (e *NameError) Error() string {
+	return fmt.Sprintf("theme %q: %v", e.Name, e.Err)
+}
+
+// Unwrap возвращает причину ошибки.
+func (e *NameError) Unwrap() error {
+	return e.Err
+}
+
+// ColorNames - допустимые названия цветов кадров.
+var ColorNames = []string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"}
+
+// Message возвращает сообщение темы об исходе игры или пустую строку, если сообщение не задано.
+func (t Theme) Message(won bool) string {
+	if won {
+		return t.Messages.Victory
+	}
+
+	return t.Messages.Defeat
+}
@@ -0,0 +1,213 @@
+package words
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/schema"
+)
+
+var (
+	// ErrInvalidWord - слово пустое или содержит не только буквы.
+	ErrInvalidWord = errors.New("invalid word")
+	// ErrWordExists - слово уже есть в словаре.
+	ErrWordExists = errors.New("word already exists")
+	// ErrUnknownWord - слова нет в категории и уровне сложности словаря.
+	ErrUnknownWord = errors.New("unknown word")
+)
+
+// Location указывает категорию и уровень сложности, в которых находится слово.
+type Location struct {
+	Category   string
+	Difficulty string
+}
+
+// String возвращает запись расположения в виде "категория/сложность".
+func (l Location) String() string {
+	return l.Category + "/" + l.Difficulty
+}
+
+// CheckWord возвращает ErrInvalidWord, если слово пустое или не содержит ни одной буквы. Остальные символы,
+// например дефис в слове «Шар-пей», допустимы: движок открывает их с начала игры.
+func CheckWord(word string) error {
+	if word == "" {
+		return fmt.Errorf("%w: word must not be empty", ErrInvalidWord)
+	}
+
+	if !strings.ContainsFunc(word, unicode.IsLetter) {
+		return fmt.Errorf("%w %q: must contain at least one letter", ErrInvalidWord, word)
+	}
+
+	return nil
+}
+
+// Find возвращает расположение слова в словаре без учёта регистра и true или пустое расположение и false,
+// если слова в словаре нет. Категории и уровни сложности просматриваются в лексикографическом порядке.
+func (ws Words) Find(word string) (Location, bool) {
+	for _, category := range schema.SortedKeys(ws) {
+		for _, difficulty := range schema.SortedKeys(ws[category]) {
+			if indexOf(ws[category][difficulty], word) != -1 {
+				return Location{Category: category, Difficulty: difficulty}, true
+			}
+		}
+	}
+
+	return Location{}, false
+}
+
+// Get возвращает данные о слове в категории и уровне сложности или ErrUnknownWord, если слова там нет.
+func (ws Words) Get(loc Location, word string) (WordData, error) {
+	i, err := ws.index(loc, word)
+	if err != nil {
+		return WordData{}, err
+	}
+
+	return ws[loc.Category][loc.Difficulty][i], nil
+}
+
+// Add добавляет данные о слове в конец списка категории и уровня сложности, создавая их при необходимости.
+// Возвращает ErrInvalidWord для недопустимого слова и ErrWordExists, если слово уже есть в словаре.
+func (ws Words) Add(loc Location, wd WordData) error {
+	err := CheckWord(wd.Word)
+	if err != nil {
+		return err
+	}
+
+	if found, ok := ws.Find(wd.Word); ok {
+		return fmt.Errorf("%w: %q in %s", ErrWordExists, wd.Word, found)
+	}
+
+	if ws[loc.Category] == nil {
+		ws[loc.Category] = make(map[string][]WordData)
+	}
+
+	ws[loc.Category][loc.Difficulty] = append(ws[loc.Category][loc.Difficulty], wd)
+
+	return nil
+}
+
+// Edit заменяет данные о слове word в категории и уровне сложности, сохраняя его место в списке.
+// Возвращает ErrUnknownWord, если слова там нет, ErrInvalidWord для недопустимого нового слова
+// и ErrWordExists, если новое слово уже есть в словаре.
+func (ws Words) Edit(loc Location, word string, wd WordData) error {
+	i, err := ws.index(loc, word)
+	if err != nil {
+		return err
+	}
+
+	err = CheckWord(wd.Word)
+	if err != nil {
+		return err
+	}
+
+	if found, ok := ws.Find(wd.Word); ok && !strings.EqualFold(wd.Word, word) {
+		return fmt.Errorf("%w: %q in %s", ErrWordExists, wd.Word, found)
+	}
+
+	ws[loc.Category][loc.Difficulty][i] = wd
+
+	return nil
+}
+
+// Remove удаляет слово из категории и уровня сложности и возвращает его данные.
+// Опустевшие уровень сложности и категория удаляются, так как словарь не допускает пустых списков слов.
+// Возвращает ErrUnknownWord, если слова там нет.
+func (ws Words) Remove(loc Location, word string) (WordData, error) {
+	i, err := ws.index(loc, word)
+	if err != nil {
+		return WordData{}, err
+	}
+
+	list := ws[loc.Category][loc.Difficulty]
+	wd := list[i]
+
+	list = slices.Delete(list, i, i+1)
+	if len(list) != 0 {
+		ws[loc.Category][loc.Difficulty] = list
+	} else {
+		ws.drop(loc)
+	}
+
+	return wd, nil
+}
+
+// Move переносит слово в конец списка другой категории и уровня сложности, создавая их при необходимости.
+// Возвращает ErrUnknownWord, если слова нет в исходных категории и уровне сложности.
+func (ws Words) Move(from Location, word string, to Location) error {
+	wd, err := ws.Remove(from, word)
+	if err != nil {
+		return err
+	}
+
+	if ws[to.Category] == nil {
+		ws[to.Category] = make(map[string][]WordData)
+	}
+
+	ws[to.Category][to.Difficulty] = append(ws[to.Category][to.Difficulty], wd)
+
+	return nil
+}
+
+// Dedup удаляет повторы слов без учёта регистра, оставляя первое вхождение при просмотре категорий
+// и уровней сложности в лексикографическом порядке, и возвращает удалённые данные о словах.
+// Опустевшие уровни сложности и категории удаляются.
+func (ws Words) Dedup() []WordData {
+	seen := make(map[string]struct{})
+
+	var removed []WordData
+
+	for _, category := range schema.SortedKeys(ws) {
+		for _, difficulty := range schema.SortedKeys(ws[category]) {
+			list := ws[category][difficulty]
+			kept := list[:0]
+
+			for _, wd := range list {
+				key := strings.ToLower(wd.Word)
+				if _, ok := seen[key]; ok {
+					removed = append(removed, wd)
+					continue
+				}
+
+				seen[key] = struct{}{}
+				kept = append(kept, wd)
+			}
+
+			if len(kept) != 0 {
+				ws[category][difficulty] = kept
+			} else {
+				ws.drop(Location{Category: category, Difficulty: difficulty})
+			}
+		}
+	}
+
+	return removed
+}
+
+// drop удаляет уровень сложности из категории и саму категорию, если в ней не осталось уровней сложности.
+func (ws Words) drop(loc Location) {
+	delete(ws[loc.Category], loc.Difficulty)
+
+	if len(ws[loc.Category]) == 0 {
+		delete(ws, loc.Category)
+	}
+}
+
+// index возвращает индекс слова в списке категории и уровня сложности или ErrUnknownWord, если слова там нет.
+func (ws Words) index(loc Location, word string) (int, error) {
+	i := indexOf(ws[loc.Category][loc.Difficulty], word)
+	if i == -1 {
+		return 0, fmt.Errorf("%w %q in %s", ErrUnknownWord, word, loc)
+	}
+
+	return i, nil
+}
+
+// indexOf возвращает индекс первого вхождения слова в список без учёта регистра или -1, если слова в списке нет.
+func indexOf(list []WordData, word string) int {
+	return slices.IndexFunc(list, func(wd WordData) bool {
+		return strings.EqualFold(wd.Word, word)
+	})
+}
@@ -0,0 +1,71 @@
+package words_test
+
+import (
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/words"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEdit(t *testing.T) {
+	easy := words.Location{Category: "пища", Difficulty: "лёгкая"}
+	hard := words.Location{Category: "пища", Difficulty: "трудная"}
+
+	ws := words.Words{
+		"пища": {
+			"лёгкая": {{Word: "Хлеб", Hints: []string{"Всему голова!"}}},
+		},
+	}
+
+	assert.NoError(t, ws.Add(easy, words.WordData{Word: "Щи", Hints: []string{"Суп"}}))
+	assert.ErrorIs(t, ws.Add(hard, words.WordData{Word: "хлеб"}), words.ErrWordExists)
+	assert.ErrorIs(t, ws.Add(hard, words.WordData{Word: "1984"}), words.ErrInvalidWord)
+
+	assert.NoError(t, ws.Edit(easy, "щи", words.WordData{Word: "Борщ", Hints: []string{"Суп со свёклой"}}))
+	assert.ErrorIs(t, ws.Edit(easy, "Щи", words.WordData{Word: "Суп"}), words.ErrUnknownWord)
+	assert.ErrorIs(t, ws.Edit(easy, "Борщ", words.WordData{Word: "Хлеб"}), words.ErrWordExists)
+
+	assert.NoError(t, ws.Move(easy, "Борщ", hard))
+	assert.Equal(t, []words.WordData{{Word: "Борщ", Hints: []string{"Суп со свёклой"}}}, ws["пища"]["трудная"])
+
+	loc, ok := ws.Find("борщ")
+	assert.True(t, ok)
+	assert.Equal(t, hard, loc)
+
+	_, err := ws.Remove(hard, "Борщ")
+	assert.NoError(t, err)
+	assert.NotContains(t, ws["пища"], "трудная")
+
+	_, err = ws.Remove(hard, "Борщ")
+	assert.ErrorIs(t, err, words.ErrUnknownWord)
+}
+
+func TestDedup(t *testing.T) {
+	ws := words.Words{
+		"персонажи": {
+			"лёгкая": {{Word: "Фродо"}, {Word: "фродо"}},
+		},
+		"пища": {
+			"лёгкая":  {{Word: "Хлеб"}},
+			"средняя": {{Word: "Фродо"}},
+		},
+	}
+
+	removed := ws.Dedup()
+
+	assert.Equal(t, []words.WordData{{Word: "фродо"}, {Word: "Фродо"}}, removed)
+	assert.Equal(t, words.Words{
+		"персонажи": {"лёгкая": {{Word: "Фродо"}}},
+		"пища":      {"лёгкая": {{Word: "Хлеб"}}},
+	}, ws)
+}
+
+func TestCheckWord(t *testing.T) {
+	for _, word := range []string{"Хлеб", "Шар-пей", "R2D2", "Улан-Удэ"} {
+		assert.NoError(t, words.CheckWord(word), word)
+	}
+
+	for _, word := range []string{"", "1984", "-"} {
+		assert.ErrorIs(t, words.CheckWord(word), words.ErrInvalidWord, word)
+	}
+}
@@ -0,0 +1,155 @@
+package words
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/schema"
+)
+
+var (
+	// ErrNoMatches - в словаре нет слов, подходящих под фильтр.
+	ErrNoMatches = errors.New("no words match the query")
+	// ErrInvalidRange - диапазон длины слова записан неверно.
+	ErrInvalidRange = errors.New("invalid length range")
+)
+
+// Query описывает фильтр слов словаря: категории, теги, диапазон длины слова, наименьшее количество
+// разных букв и слова, которые нужно исключить. Слово подходит, если оно относится к одной из категорий
+// и имеет хотя бы один из тегов. Нулевые значения полей не ограничивают выбор.
+type Query struct {
+	Categories  []string
+	Tags        []string
+	MinLength   int
+	MaxLength   int
+	MinDistinct int
+	Exclude     map[string]struct{}
+}
+
+// Entry хранит данные о слове вместе с его расположением в словаре.
+type Entry struct {
+	Location
+	WordData
+}
+
+// HasFilters возвращает true, если фильтр ограничивает выбор не только одной категорией, иначе false.
+func (q Query) HasFilters() bool {
+	return len(q.Categories) > 1 || len(q.Tags) != 0 || q.MinLength != 0 || q.MaxLength != 0 ||
+		q.MinDistinct != 0 || len(q.Exclude) != 0
+}
+
+// Match возвращает true, если слово категории category подходит под фильтр, иначе false.
+// Категории и теги сравниваются без учёта регистра, исключаемые слова задаются в нижнем регистре.
+func (q Query) Match(category string, wd WordData) bool {
+	if len(q.Categories) != 0 && !containsFold(q.Categories, category) {
+		return false
+	}
+
+	if len(q.Tags) != 0 && !slices.ContainsFunc(wd.Tags, func(tag string) bool { return containsFold(q.Tags, tag) }) {
+		return false
+	}
+
+	length := utf8.RuneCountInString(wd.Word)
+	if length < q.MinLength || (q.MaxLength != 0 && length > q.MaxLength) {
+		return false
+	}
+
+	if distinctLetters(wd.Word) < q.MinDistinct {
+		return false
+	}
+
+	_, excluded := q.Exclude[strings.ToLower(wd.Word)]
+
+	return !excluded
+}
+
+// Select возвращает слова уровня сложности difficulty, подходящие под фильтр, упорядоченные по категориям
+// в лексикографическом порядке и по месту в списке слов, чтобы выбор при одинаковом зерне генератора был одинаковым.
+func (ws Words) Select(q Query, difficulty string) []Entry {
+	var entries []Entry
+
+	for _, category := range schema.SortedKeys(ws) {
+		for _, wd := range ws[category][difficulty] {
+			if q.Match(category, wd) {
+				entries = append(entries, Entry{Location: Location{Category: category, Difficulty: difficulty}, WordData: wd})
+			}
+		}
+	}
+
+	return entries
+}
+
+// Tags возвращает упорядоченные теги всех слов словаря без повторов.
+func (ws Words) Tags() []string {
+	set := make(map[string]struct{})
+
+	for _, difficulties := range ws {
+		for _, list := range difficulties {
+			for _, wd := range list {
+				for _, tag := range wd.Tags {
+					set[tag] = struct{}{}
+				}
+			}
+		}
+	}
+
+	return schema.SortedKeys(set)
+}
+
+// ParseLengthRange разбирает диапазон длины слова вида "5-8", "5-", "-8" или "6" и возвращает его границы.
+// Отсутствующая граница равна 0 и не ограничивает длину.
+func ParseLengthRange(s string) (minLength, maxLength int, err error) {
+	lower, upper, isRange := strings.Cut(strings.TrimSpace(s), "-")
+	if !isRange {
+		upper = lower
+	}
+
+	bounds := [2]int{}
+
+	for i, bound := range []string{lower, upper} {
+		bound = strings.TrimSpace(bound)
+		if bound == "" {
+			continue
+		}
+
+		bounds[i], err = strconv.Atoi(bound)
+		if err != nil || bounds[i] < 1 {
+			return 0, 0, fmt.Errorf("%w %q: bounds must be positive integers", ErrInvalidRange, s)
+		}
+	}
+
+	if bounds[0] == 0 && bounds[1] == 0 {
+		return 0, 0, fmt.Errorf("%w %q: at least one bound is required", ErrInvalidRange, s)
+	}
+
+	if bounds[1] != 0 && bounds[0] > bounds[1] {
+		return 0, 0, fmt.Errorf("%w %q: lower bound is greater than upper bound", ErrInvalidRange, s)
+	}
+
+	return bounds[0], bounds[1], nil
+}
+
+// distinctLetters возвращает количество разных букв слова без учёта регистра.
+func distinctLetters(word string) int {
+	set := make(map[rune]struct{})
+
+	for _, r := range word {
+		if unicode.IsLetter(r) {
+			set[unicode.ToLower(r)] = struct{}{}
+		}
+	}
+
+	return len(set)
+}
+
+// containsFold возвращает true, если список содержит строку без учёта регистра, иначе false.
+func containsFold(list []string, s string) bool {
+	return slices.ContainsFunc(list, func(item string) bool {
+		return strings.EqualFold(item, s)
+	})
+}
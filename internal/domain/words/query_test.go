@@ -0,0 +1,78 @@
+package words_test
+
+import (
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/words"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelect(t *testing.T) {
+	ws := words.Words{
+		"пища": {
+			"лёгкая": {
+				{Word: "Хлеб", Tags: []string{"выпечка"}},
+				{Word: "Щи", Tags: []string{"суп"}},
+				{Word: "Пирог", Tags: []string{"Выпечка"}},
+			},
+		},
+		"животные": {
+			"лёгкая": {{Word: "Кот"}, {Word: "Собака"}},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		query words.Query
+		want  []string
+	}{
+		{name: "all", query: words.Query{}, want: []string{"Кот", "Собака", "Хлеб", "Щи", "Пирог"}},
+		{name: "categories", query: words.Query{Categories: []string{"Пища"}}, want: []string{"Хлеб", "Щи", "Пирог"}},
+		{name: "tags", query: words.Query{Tags: []string{"выпечка"}}, want: []string{"Хлеб", "Пирог"}},
+		{name: "length", query: words.Query{MinLength: 4, MaxLength: 5}, want: []string{"Хлеб", "Пирог"}},
+		{name: "distinct", query: words.Query{MinDistinct: 5}, want: []string{"Собака", "Пирог"}},
+		{
+			name:  "exclude",
+			query: words.Query{Categories: []string{"животные"}, Exclude: map[string]struct{}{"кот": {}}},
+			want:  []string{"Собака"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, entry := range ws.Select(tt.query, "лёгкая") {
+				got = append(got, entry.Word)
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseLengthRange(t *testing.T) {
+	tests := []struct {
+		s        string
+		min, max int
+		err      bool
+	}{
+		{s: "5-8", min: 5, max: 8},
+		{s: "5-", min: 5},
+		{s: "-8", max: 8},
+		{s: "6", min: 6, max: 6},
+		{s: "8-5", err: true},
+		{s: "-", err: true},
+		{s: "пять", err: true},
+	}
+
+	for _, tt := range tests {
+		minLength, maxLength, err := words.ParseLengthRange(tt.s)
+		if tt.err {
+			assert.ErrorIs(t, err, words.ErrInvalidRange, tt.s)
+			continue
+		}
+
+		assert.NoError(t, err, tt.s)
+		assert.Equal(t, [2]int{tt.min, tt.max}, [2]int{minLength, maxLength}, tt.s)
+	}
+}
@@ -0,0 +1,127 @@
+package words
+
+import (
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/schema"
+)
+
+// Validate проверяет соответствие декодированного словаря схеме и возвращает все найденные нарушения.
+func Validate(raw any, source string) schema.Problems {
+	var problems schema.Problems
+
+	categories, ok := schema.Object(&problems, source, schema.Root, raw)
+	if !ok {
+		return problems
+	}
+
+	for _, category := range schema.SortedKeys(categories) {
+		categoryPath := schema.Key(schema.Root, category)
+
+		difficulties, ok := schema.Object(&problems, source, categoryPath, categories[category])
+		if !ok {
+			continue
+		}
+
+		for _, difficulty := range schema.SortedKeys(difficulties) {
+			validateWordList(&problems, source, schema.Key(categoryPath, difficulty), difficulties[difficulty])
+		}
+	}
+
+	return problems
+}
+
+// validateWordList проверяет список данных о словах одного уровня сложности.
+func validateWordList(problems *schema.Problems, source, path string, raw any) {
+	list, ok := schema.Array(problems, source, path, raw)
+	if !ok {
+		return
+	}
+
+	if len(list) == 0 {
+		problems.Add(source, path, "word list must not be empty")
+	}
+
+	for i, item := range list {
+		itemPath := schema.Index(path, i)
+
+		wd, ok := schema.Object(problems, source, itemPath, item)
+		if !ok {
+			continue
+		}
+
+		validateWordData(problems, source, itemPath, wd)
+	}
+}
+
+// validateWordData проверяет данные об одном слове. Подсказка задаётся строкой в поле hint
+// или непустым массивом строк в поле hints, но не обоими полями сразу.
+func validateWordData(problems *schema.Problems, source, path string, wd map[string]any) {
+	hint, hasHint := wd["hint"]
+	hints, hasHints := wd["hints"]
+
+	if !hasHint && !hasHints {
+		problems.Add(source, schema.Key(path, "hint"), "required field is missing")
+	}
+
+	optional := []string{"hint", "hints", "tags", "weight", "explanation", "source"}
+	schema.Fields(problems, source, path, wd, []string{"word"}, optional)
+
+	if word, ok := wd["word"]; ok {
+		validateWord(problems, source, schema.Key(path, "word"), word)
+	}
+
+	switch {
+	case hasHint && hasHints:
+		problems.Add(source, schema.Key(path, "hints"), "must not be used together with hint")
+	case hasHint:
+		schema.String(problems, source, schema.Key(path, "hint"), hint)
+	case hasHints:
+		list := validateStrings(problems, source, schema.Key(path, "hints"), hints)
+		if list != nil && len(list) == 0 {
+			problems.Add(source, schema.Key(path, "hints"), "hint list must not be empty")
+		}
+	}
+
+	if tags, ok := wd["tags"]; ok {
+		validateStrings(problems, source, schema.Key(path, "tags"), tags)
+	}
+
+	if raw, ok := wd["weight"]; ok {
+		weight, ok := schema.Number(problems, source, schema.Key(path, "weight"), raw)
+		if ok && weight <= 0 {
+			problems.Add(source, schema.Key(path, "weight"), "must be positive, got %v", weight)
+		}
+	}
+
+	for _, key := range []string{"explanation", "source"} {
+		if value, ok := wd[key]; ok {
+			schema.String(problems, source, schema.Key(path, key), value)
+		}
+	}
+}
+
+// validateStrings проверяет, что значение является массивом строк, и возвращает массив или nil при несоответствии типа.
+func validateStrings(problems *schema.Problems, source, path string, raw any) []any {
+	list, ok := schema.Array(problems, source, path, raw)
+	if !ok {
+		return nil
+	}
+
+	for i, item := range list {
+		schema.String(problems, source, schema.Index(path, i), item)
+	}
+
+	return list
+}
+
+// validateWord проверяет, что слово является строкой, допустимой по правилам CheckWord.
+func validateWord(problems *schema.Problems, source, path string, raw any) {
+	word, ok := schema.String(problems, source, path, raw)
+	if !ok {
+		return
+	}
+
+	err := CheckWord(word)
+	if err != nil {
+		problems.Add(source, path, "%v", err)
+	}
+}
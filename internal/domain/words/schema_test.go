@@ -0,0 +1,67 @@
+package words_test
+
+import (
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/words"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	tt := []struct {
+		raw   any
+		paths []string
+	}{
+		{
+			raw: map[string]any{
+				"персонажи": map[string]any{
+					"лёгкая": []any{map[string]any{"word": "Фродо", "hint": "Хранитель кольца"}},
+				},
+			},
+			paths: nil,
+		},
+		{
+			raw:   []any{},
+			paths: []string{"$"},
+		},
+		{
+			raw: map[string]any{
+				"персонажи": map[string]any{
+					"лёгкая":  []any{map[string]any{"word": "Фродо", "hnt": "Хранитель кольца"}},
+					"средняя": []any{map[string]any{"word": "1984", "hint": 2.0}},
+					"трудная": []any{},
+				},
+				"пища": map[string]any{
+					"лёгкая": []any{
+						map[string]any{
+							"word": "Щи", "hints": []any{"суп", "из капусты"}, "tags": []any{"супы"},
+							"weight": 2.5, "explanation": "Щи да каша — пища наша", "source": "пословица",
+						},
+						map[string]any{"word": "Борщ", "hint": "суп", "hints": []any{}, "weight": 0.0, "tags": "супы"},
+					},
+				},
+			},
+			paths: []string{
+				"$.персонажи.лёгкая[0].hint",
+				"$.персонажи.лёгкая[0].hnt",
+				"$.персонажи.средняя[0].word",
+				"$.персонажи.средняя[0].hint",
+				"$.персонажи.трудная",
+				"$.пища.лёгкая[1].hints",
+				"$.пища.лёгкая[1].tags",
+				"$.пища.лёгкая[1].weight",
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		problems := words.Validate(tc.raw, "words.json")
+
+		var paths []string
+		for _, p := range problems {
+			paths = append(paths, p.Path)
+		}
+
+		assert.Equal(t, tc.paths, paths)
+	}
+}
@@ -0,0 +1,185 @@
+package words
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/random"
+)
+
+// Стратегии выбора слова.
+const (
+	// StrategyUniform - равновероятный выбор, не зависящий от предыдущих игр.
+	StrategyUniform = "uniform"
+	// StrategyWeighted - выбор с вероятностью, пропорциональной весу слова.
+	StrategyWeighted = "weighted"
+	// StrategyBag - выбор по весу среди ещё не загаданных слов: слово повторяется,
+	// только когда загаданы все слова, из которых идёт выбор.
+	StrategyBag = "bag"
+)
+
+// Strategies - допустимые стратегии выбора слова.
+var Strategies = []string{StrategyUniform, StrategyWeighted, StrategyBag}
+
+// ErrUnknownStrategy - неизвестная стратегия выбора слова.
+var ErrUnknownStrategy = errors.New("unknown selection strategy")
+
+// weightScale - количество равных частей, на которые делится суммарный вес слов при взвешенном выборе.
+const weightScale = 1 << 30
+
+// Selector описывает стратегию выбора слова из подходящих слов словаря.
+type Selector interface {
+	Choose(rnd random.Source, entries []Entry) (Entry, error)
+}
+
+// Uniform выбирает каждое слово с равной вероятностью.
+type Uniform struct{}
+
+// Weighted выбирает слово с вероятностью, пропорциональной его весу. Слово без веса имеет вес 1.
+type Weighted struct{}
+
+// Bag выбирает слово по весу среди слов, которые ещё не были загаданы, и запоминает загаданные слова
+// отдельно для каждой категории и уровня сложности. Когда незагаданных слов среди подходящих не остаётся,
+// подходящие слова снова становятся доступными. Состояние можно сохранить между запусками программы.
+type Bag struct {
+	Served map[string][]string
+}
+
+// NewSelector возвращает стратегию выбора слова по её названию. Стратегия StrategyBag использует переданное состояние bag
+// или новое состояние, если bag равен nil.
+func NewSelector(strategy string, bag *Bag) (Selector, error) {
+	if bag == nil {
+		bag = NewBag()
+	}
+
+	switch strategy {
+	case StrategyUniform:
+		return Uniform{}, nil
+	case StrategyWeighted:
+		return Weighted{}, nil
+	case StrategyBag:
+		return bag, nil
+	default:
+		return nil, fmt.Errorf("%w %q, expected one of %v", ErrUnknownStrategy, strategy, Strategies)
+	}
+}
+
+// Choose возвращает случайное слово из entries или ErrNoMatches, если entries пуст.
+func (Uniform) Choose(rnd random.Source, entries []Entry) (Entry, error) {
+	if len(entries) == 0 {
+		return Entry{}, ErrNoMatches
+	}
+
+	i, err := rnd.Int(len(entries))
+	if err != nil {
+		return Entry{}, fmt.Errorf("can`t choose word: %w", err)
+	}
+
+	return entries[i], nil
+}
+
+// Choose возвращает слово из entries, выбранное по весу, или ErrNoMatches, если entries пуст.
+func (Weighted) Choose(rnd random.Source, entries []Entry) (Entry, error) {
+	return chooseWeighted(rnd, entries)
+}
+
+// NewBag возвращает указатель на Bag, в котором ещё нет загаданных слов.
+func NewBag() *Bag {
+	return &Bag{Served: make(map[string][]string)}
+}
+
+// Choose возвращает слово из entries, выбранное по весу среди незагаданных, и отмечает его загаданным.
+// Если загаданы все слова entries, отметки с них снимаются. Для пустого entries возвращает ErrNoMatches.
+func (b *Bag) Choose(rnd random.Source, entries []Entry) (Entry, error) {
+	if b.Served == nil {
+		b.Served = make(map[string][]string)
+	}
+
+	remaining := slices.DeleteFunc(slices.Clone(entries), b.isServed)
+	if len(remaining) == 0 {
+		for _, e := range entries {
+			b.unmark(e)
+		}
+
+		remaining = entries
+	}
+
+	entry, err := chooseWeighted(rnd, remaining)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	b.mark(entry)
+
+	return entry, nil
+}
+
+// isServed возвращает true, если слово уже было загадано, иначе false.
+func (b *Bag) isServed(e Entry) bool {
+	_, found := slices.BinarySearch(b.Served[e.Location.String()], strings.ToLower(e.Word))
+	return found
+}
+
+// mark отмечает слово загаданным, сохраняя список загаданных слов упорядоченным.
+func (b *Bag) mark(e Entry) {
+	key, word := e.Location.String(), strings.ToLower(e.Word)
+
+	i, found := slices.BinarySearch(b.Served[key], word)
+	if !found {
+		b.Served[key] = slices.Insert(b.Served[key], i, word)
+	}
+}
+
+// unmark снимает со слова отметку о том, что оно загадано, удаляя пустые списки.
+func (b *Bag) unmark(e Entry) {
+	key, word := e.Location.String(), strings.ToLower(e.Word)
+
+	i, found := slices.BinarySearch(b.Served[key], word)
+	if !found {
+		return
+	}
+
+	b.Served[key] = slices.Delete(b.Served[key], i, i+1)
+	if len(b.Served[key]) == 0 {
+		delete(b.Served, key)
+	}
+}
+
+// chooseWeighted возвращает слово из entries с вероятностью, пропорциональной его весу, или ErrNoMatches, если entries пуст.
+func chooseWeighted(rnd random.Source, entries []Entry) (Entry, error) {
+	if len(entries) == 0 {
+		return Entry{}, ErrNoMatches
+	}
+
+	total := 0.0
+	for _, e := range entries {
+		total += weight(e.WordData)
+	}
+
+	n, err := rnd.Int(weightScale)
+	if err != nil {
+		return Entry{}, fmt.Errorf("can`t choose word: %w", err)
+	}
+
+	point := float64(n) / weightScale * total
+
+	for _, e := range entries {
+		point -= weight(e.WordData)
+		if point < 0 {
+			return e, nil
+		}
+	}
+
+	return entries[len(entries)-1], nil
+}
+
+// weight возвращает вес слова или 1, если вес не задан.
+func weight(wd WordData) float64 {
+	if wd.Weight <= 0 {
+		return 1
+	}
+
+	return wd.Weight
+}
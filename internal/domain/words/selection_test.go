@@ -0,0 +1,67 @@
+package words_test
+
+import (
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/random"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/words"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBag(t *testing.T) {
+	loc := words.Location{Category: "пища", Difficulty: "лёгкая"}
+	entries := []words.Entry{
+		{Location: loc, WordData: words.WordData{Word: "Хлеб"}},
+		{Location: loc, WordData: words.WordData{Word: "Щи", Weight: 100}},
+		{Location: loc, WordData: words.WordData{Word: "Пирог", Weight: 0.01}},
+	}
+
+	bag := words.NewBag()
+	rnd := random.New(0)
+
+	for range 3 {
+		served := make(map[string]struct{})
+
+		for range len(entries) {
+			entry, err := bag.Choose(rnd, entries)
+			assert.NoError(t, err)
+			assert.NotContains(t, served, entry.Word)
+
+			served[entry.Word] = struct{}{}
+		}
+	}
+
+	_, err := bag.Choose(rnd, nil)
+	assert.ErrorIs(t, err, words.ErrNoMatches)
+}
+
+func TestWeighted(t *testing.T) {
+	entries := []words.Entry{
+		{WordData: words.WordData{Word: "Хлеб", Weight: 1}},
+		{WordData: words.WordData{Word: "Щи", Weight: 99}},
+	}
+
+	rnd := random.New(1)
+	counts := make(map[string]int)
+
+	for range 1000 {
+		entry, err := words.Weighted{}.Choose(rnd, entries)
+		assert.NoError(t, err)
+
+		counts[entry.Word]++
+	}
+
+	assert.Greater(t, counts["Щи"], 900)
+	assert.Positive(t, counts["Хлеб"])
+}
+
+func TestNewSelector(t *testing.T) {
+	for _, strategy := range words.Strategies {
+		selector, err := words.NewSelector(strategy, nil)
+		assert.NoError(t, err)
+		assert.NotNil(t, selector)
+	}
+
+	_, err := words.NewSelector("lottery", nil)
+	assert.ErrorIs(t, err, words.ErrUnknownStrategy)
+}
@@ -1,7 +1,85 @@
 package words
 
-// WordData хранит загаданное слово и подсказку к нему.
+import (
+	"encoding/json"
+)
+
+// WordData хранит загаданное слово, подсказки к нему в порядке раскрытия, теги, вес при выборе слова,
+// пояснение, которое показывается после игры, и источник или автора слова.
+// Нулевой вес означает вес по-умолчанию.
 type WordData struct {
-	Word string
-	Hint string
+	Word        string
+	Hints       []string
+	Tags        []string
+	Weight      float64
+	Explanation string
+	Source      string
+}
+
+// wordObject - запись данных о слове в файле словаря. Единственная подсказка записывается строкой в поле hint,
+// как в исходном формате словаря из двух полей, а несколько подсказок - массивом в поле hints.
+type wordObject struct {
+	Word        string   `json:"word"`
+	Hint        *string  `json:"hint,omitempty"`
+	Hints       []string `json:"hints,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Weight      float64  `json:"weight,omitempty"`
+	Explanation string   `json:"explanation,omitempty"`
+	Source      string   `json:"source,omitempty"`
+}
+
+// UnmarshalJSON декодирует данные о слове с подсказкой в поле hint или подсказками в поле hints.
+// Пустая подсказка в поле hint означает, что подсказок нет.
+func (wd *WordData) UnmarshalJSON(data []byte) error {
+	var obj wordObject
+
+	err := json.Unmarshal(data, &obj)
+	if err != nil {
+		return err
+	}
+
+	hints := obj.Hints
+	if obj.Hint != nil && *obj.Hint != "" {
+		hints = append([]string{*obj.Hint}, hints...)
+	}
+
+	*wd = WordData{
+		Word:        obj.Word,
+		Hints:       hints,
+		Tags:        obj.Tags,
+		Weight:      obj.Weight,
+		Explanation: obj.Explanation,
+		Source:      obj.Source,
+	}
+
+	return nil
+}
+
+// MarshalJSON кодирует данные о слове, записывая не более одной подсказки в поле hint, а несколько - в поле hints.
+func (wd WordData) MarshalJSON() ([]byte, error) {
+	obj := wordObject{
+		Word:        wd.Word,
+		Tags:        wd.Tags,
+		Weight:      wd.Weight,
+		Explanation: wd.Explanation,
+		Source:      wd.Source,
+	}
+
+	if len(wd.Hints) > 1 {
+		obj.Hints = wd.Hints
+	} else {
+		hint := wd.Hint()
+		obj.Hint = &hint
+	}
+
+	return json.Marshal(obj)
+}
+
+// Hint возвращает первую подсказку к слову или пустую строку, если подсказок нет.
+func (wd WordData) Hint() string {
+	if len(wd.Hints) == 0 {
+		return ""
+	}
+
+	return wd.Hints[0]
 }
@@ -0,0 +1,48 @@
+package words_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/words"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordDataJSON(t *testing.T) {
+	tt := []struct {
+		encoded string
+		wd      words.WordData
+	}{
+		{
+			encoded: `{"word":"Фродо","hint":"Хранитель кольца"}`,
+			wd:      words.WordData{Word: "Фродо", Hints: []string{"Хранитель кольца"}},
+		},
+		{
+			encoded: `{"word":"Фродо","hint":""}`,
+			wd:      words.WordData{Word: "Фродо"},
+		},
+		{
+			encoded: `{"word":"Фродо","hints":["Хранитель кольца","Хоббит"],"tags":["книги"],"weight":2,` +
+				`"explanation":"Персонаж «Властелина колец»","source":"Толкин"}`,
+			wd: words.WordData{
+				Word:        "Фродо",
+				Hints:       []string{"Хранитель кольца", "Хоббит"},
+				Tags:        []string{"книги"},
+				Weight:      2,
+				Explanation: "Персонаж «Властелина колец»",
+				Source:      "Толкин",
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		var wd words.WordData
+
+		assert.NoError(t, json.Unmarshal([]byte(tc.encoded), &wd))
+		assert.Equal(t, tc.wd, wd)
+
+		encoded, err := json.Marshal(tc.wd)
+		assert.NoError(t, err)
+		assert.JSONEq(t, tc.encoded, string(encoded))
+	}
+}
@@ -1,14 +1,94 @@
 package words
 
 import (
+	"errors"
+	"fmt"
+	"strings"
+
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/random"
 )
 
+var (
+	// ErrUnknownCategory - категории нет в словаре.
+	ErrUnknownCategory = errors.New("unknown category")
+	// ErrUnknownDifficulty - уровня сложности нет в категории словаря.
+	ErrUnknownDifficulty = errors.New("unknown difficulty")
+	// ErrEmptyWordList - в категории словаря нет слов указанного уровня сложности.
+	ErrEmptyWordList = errors.New("empty word list")
+)
+
+// ConditionError - ошибка выбора слова по условиям игры: категории Category и уровню сложности Difficulty.
+// Пустые условия не заданы. Err - одна из ошибок ErrUnknownCategory, ErrUnknownDifficulty, ErrEmptyWordList, ErrNoMatches.
+type ConditionError struct {
+	Category   string
+	Difficulty string
+	Err        error
+}
+
+// Error возвращает описание ошибки.
+func (e *ConditionError) Error() string {
+	var conditions []string
+
+	if e.Category != "" {
+		conditions = append(conditions, fmt.Sprintf("category %q", e.Category))
+	}
+
+	if e.Difficulty != "" {
+		conditions = append(conditions, fmt.Sprintf("difficulty %q", e.Difficulty))
+	}
+
+	return fmt.Sprintf("%s: %v", strings.Join(conditions, ", "), e.Err)
+}
+
+// Unwrap возвращает причину ошибки.
+func (e *ConditionError) Unwrap() error {
+	return e.Err
+}
+
 // Words - cловарь, сопоставляющий категории и сложности слайс данных о слове.
 type Words map[string]map[string][]WordData
 
-// GetRandomWordData возвращает случайное слово из словаря.
-func (ws Words) GetRandomWordData(category, difficulty string) WordData {
-	randIndex := random.RandInt(len(ws[category][difficulty]))
-	return ws[category][difficulty][randIndex]
+// GetRandomWordData возвращает случайное слово из словаря, выбранное с помощью переданного источника.
+// Для отсутствующих категории и уровня сложности возвращаются ErrUnknownCategory и ErrUnknownDifficulty,
+// для пустого списка слов - ErrEmptyWordList.
+func (ws Words) GetRandomWordData(rnd random.Source, category, difficulty string) (WordData, error) {
+	entries, err := ws.Entries(category, difficulty)
+	if err != nil {
+		return WordData{}, err
+	}
+
+	entry, err := Uniform{}.Choose(rnd, entries)
+	if err != nil {
+		return WordData{}, err
+	}
+
+	return entry.WordData, nil
+}
+
+// Entries возвращает слова категории category уровня сложности difficulty вместе с их расположением.
+// Для отсутствующих категории и уровня сложности возвращаются ErrUnknownCategory и ErrUnknownDifficulty,
+// для пустого списка слов - ErrEmptyWordList, обёрнутые в *ConditionError.
+func (ws Words) Entries(category, difficulty string) ([]Entry, error) {
+	difficulties, ok := ws[category]
+	if !ok {
+		return nil, &ConditionError{Category: category, Err: ErrUnknownCategory}
+	}
+
+	wordsData, ok := difficulties[difficulty]
+	if !ok {
+		return nil, &ConditionError{Category: category, Difficulty: difficulty, Err: ErrUnknownDifficulty}
+	}
+
+	if len(wordsData) == 0 {
+		return nil, &ConditionError{Category: category, Difficulty: difficulty, Err: ErrEmptyWordList}
+	}
+
+	loc := Location{Category: category, Difficulty: difficulty}
+
+	entries := make([]Entry, 0, len(wordsData))
+	for _, wd := range wordsData {
+		entries = append(entries, Entry{Location: loc, WordData: wd})
+	}
+
+	return entries, nil
 }
@@ -1,8 +1,10 @@
 package words_test
 
 import (
+	"reflect"
 	"testing"
 
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/random"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/words"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/loader"
 	"github.com/stretchr/testify/assert"
@@ -37,13 +39,18 @@ func TestGetRandomWordData(t *testing.T) {
 		err := loader.LoadDataFromFile("../../infrastructure/files/words.json", &ws)
 		assert.NoError(t, err)
 
-		word := ws.GetRandomWordData(tc.category, tc.difficulty)
+		seed, err := random.NewSeed()
+		assert.NoError(t, err)
+
+		word, err := ws.GetRandomWordData(random.New(seed), tc.category, tc.difficulty)
+		assert.NoError(t, err)
+
 		wordsData := ws[tc.category][tc.difficulty]
 
 		ok := false
 
 		for _, wd := range wordsData {
-			if wd == word {
+			if reflect.DeepEqual(wd, word) {
 				ok = true
 				break
 			}
@@ -52,3 +59,38 @@ func TestGetRandomWordData(t *testing.T) {
 		assert.Equal(t, tc.correspond, ok)
 	}
 }
+
+func TestGetRandomWordDataReproducible(t *testing.T) {
+	ws := make(words.Words)
+
+	err := loader.LoadDataFromFile("../../infrastructure/files/words.json", &ws)
+	assert.NoError(t, err)
+
+	for seed := uint64(0); seed < 10; seed++ {
+		first, err := ws.GetRandomWordData(random.New(seed), "персонажи", "лёгкая")
+		assert.NoError(t, err)
+
+		second, err := ws.GetRandomWordData(random.New(seed), "персонажи", "лёгкая")
+		assert.NoError(t, err)
+
+		assert.Equal(t, first, second)
+	}
+}
+
+func TestGetRandomWordDataErrors(t *testing.T) {
+	ws := words.Words{
+		"пища": {
+			"лёгкая":  {{Word: "суп", Hints: []string{"первое блюдо"}}},
+			"трудная": {},
+		},
+	}
+
+	_, err := ws.GetRandomWordData(random.New(0), "спорт", "лёгкая")
+	assert.ErrorIs(t, err, words.ErrUnknownCategory)
+
+	_, err = ws.GetRandomWordData(random.New(0), "пища", "средняя")
+	assert.ErrorIs(t, err, words.ErrUnknownDifficulty)
+
+	_, err = ws.GetRandomWordData(random.New(0), "пища", "трудная")
+	assert.ErrorIs(t, err, words.ErrEmptyWordList)
+}
@@ -0,0 +1,121 @@
+package console
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/frames"
+)
+
+// displayAccessibleStatus выводит статус сессии в режиме специальных возможностей: вместо кадра выводится его описание,
+// отображаемое слово проговаривается по позициям, а результат последней попытки объявляется отдельной строкой.
+func (gc *GameConsole) displayAccessibleStatus(
+	category, difficulty string,
+	fr frames.Frame,
+	displayedWord []rune,
+	attempts int,
+	lettersUsed map[rune]struct{},
+) {
+	gc.write(border, 2)
+	gc.writef(1, gc.msg.categoryForm, gc.categoryName(category))
+	gc.writef(2, gc.msg.difficultyForm, difficulty)
+	gc.writeAnnouncement(displayedWord, attempts, lettersUsed, 1)
+	gc.writeDescription(fr, 1)
+	gc.writeLettersUsed(lettersUsed, displayedWord, 1)
+	gc.writef(1, gc.msg.attemptsForm, attempts)
+	gc.writeSpelledWord(displayedWord, 2)
+	gc.flush()
+}
+
+// writeAnnouncement пишет в gc.writer результат попытки, сделанной после предыдущего вывода статуса: позиции,
+// на которых открыта новая буква, или сообщение о её отсутствии в слове, угаданное или неверно названное слово
+// или отмену попытки. Новые буквы объявляются по алфавиту.
+func (gc *GameConsole) writeAnnouncement(displayedWord []rune, attempts int, lettersUsed map[rune]struct{}, indents int) {
+	hasPrevious := len(gc.displayedWord) == len(displayedWord)
+
+	if hasPrevious && gc.isUndone(displayedWord, attempts, lettersUsed) {
+		gc.write(gc.msg.undone, indents)
+		return
+	}
+
+	announced := false
+
+	for _, letter := range sortedLetters(lettersUsed) {
+		if _, ok := gc.lettersUsed[letter]; ok {
+			continue
+		}
+
+		var positions []string
+
+		for i, r := range displayedWord {
+			if r == letter {
+				positions = append(positions, fmt.Sprint(i+1))
+			}
+		}
+
+		if len(positions) == 0 {
+			gc.writef(indents, gc.msg.missForm, string(letter))
+		} else {
+			gc.writef(indents, gc.msg.revealForm, string(letter), strings.Join(positions, ", "))
+		}
+
+		announced = true
+	}
+
+	switch {
+	case !hasPrevious || announced:
+	case !slices.Equal(gc.displayedWord, displayedWord):
+		gc.writef(indents, gc.msg.wordHitForm, string(displayedWord))
+	case attempts < gc.attempts:
+		gc.write(gc.msg.wordMiss, indents)
+	}
+}
+
+// isUndone возвращает true, если по сравнению с предыдущим выводом статуса попытка отменена: попыток стало больше,
+// использованная буква забыта или открытая позиция снова скрыта, иначе false.
+func (gc *GameConsole) isUndone(displayedWord []rune, attempts int, lettersUsed map[rune]struct{}) bool {
+	if attempts > gc.attempts {
+		return true
+	}
+
+	for letter := range gc.lettersUsed {
+		if _, ok := lettersUsed[letter]; !ok {
+			return true
+		}
+	}
+
+	for i, r := range gc.displayedWord {
+		if r != '_' && displayedWord[i] == '_' {
+			return true
+		}
+	}
+
+	return false
+}
+
+// writeDescription пишет в gc.writer текстовое описание кадра.
+func (gc *GameConsole) writeDescription(fr frames.Frame, indents int) {
+	description := fr.Description
+	if description == "" {
+		description = gc.msg.noDescription
+	}
+
+	gc.writef(indents, gc.msg.pictureForm, description)
+}
+
+// writeSpelledWord пишет в gc.writer отображаемое слово по позициям, называя неоткрытые позиции пустыми.
+func (gc *GameConsole) writeSpelledWord(displayedWord []rune, indents int) {
+	positions := make([]string, 0, len(displayedWord))
+
+	for i, letter := range displayedWord {
+		text := string(letter)
+		if letter == '_' {
+			text = gc.msg.emptyPosition
+		}
+
+		positions = append(positions, fmt.Sprintf(gc.msg.positionForm, i+1, text))
+	}
+
+	gc.writef(indents, gc.msg.wordForm, len(displayedWord), strings.Join(positions, ", "))
+}
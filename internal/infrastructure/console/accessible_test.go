@@ -0,0 +1,137 @@
+package console_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/frames"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/console"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccessibleStatus(t *testing.T) {
+	var out bytes.Buffer
+
+	gc, err := console.NewWithIO(console.Options{Lang: "ru", Color: "always", Accessible: true}, strings.NewReader(""), &out, true)
+	assert.NoError(t, err)
+
+	fr := frames.Frame{Lines: []string{"{red}O{/}"}, Description: "появилась голова"}
+
+	gc.DisplaySessionStatus("пища", "лёгкая", fr, []rune("_а_а"), 5, map[rune]struct{}{'а': {}})
+
+	first := out.String()
+	assert.Contains(t, first, "Рисунок: появилась голова.")
+	assert.Contains(t, first, "Букв в слове: 4. 1: пусто, 2: а, 3: пусто, 4: а.")
+	assert.Contains(t, first, "Буква а открыта на позициях: 2, 4.")
+	assert.NotContains(t, first, "\x1b[")
+	assert.NotContains(t, first, "O")
+
+	out.Reset()
+	gc.DisplaySessionStatus("пища", "лёгкая", frames.Frame{}, []rune("_а_а"), 4, map[rune]struct{}{'а': {}, 'к': {}})
+
+	second := out.String()
+	assert.Contains(t, second, "Буквы к нет в слове.")
+	assert.Contains(t, second, "Рисунок: описание отсутствует.")
+	assert.NotContains(t, second, "Буква а открыта")
+}
+
+func TestAccessibleAnimation(t *testing.T) {
+	var out bytes.Buffer
+
+	gc, err := console.NewWithIO(console.Options{Lang: "en", Accessible: true}, strings.NewReader(""), &out, true)
+	assert.NoError(t, err)
+
+	frs := []frames.Frame{{Lines: []string{"1"}, Description: "first"}, {Lines: []string{"2"}, Description: "last"}}
+	gc.PlayAnimation(context.Background(), "victory", frs, 10_000)
+
+	assert.NotContains(t, out.String(), "first")
+	assert.Contains(t, out.String(), "last")
+}
+
+func TestAccessibleAnnouncements(t *testing.T) {
+	type status struct {
+		displayedWord string
+		attempts      int
+		lettersUsed   string
+	}
+
+	tests := []struct {
+		name     string
+		previous status
+		current  status
+		expected []string
+	}{
+		{
+			name:     "several new letters in alphabetical order",
+			previous: status{displayedWord: "____", attempts: 5},
+			current:  status{displayedWord: "_а_а", attempts: 4, lettersUsed: "каб"},
+			expected: []string{"Буква а открыта на позициях: 2, 4.", "Буквы б нет в слове.", "Буквы к нет в слове."},
+		},
+		{
+			name:     "whole word guessed",
+			previous: status{displayedWord: "_а_а", attempts: 5, lettersUsed: "а"},
+			current:  status{displayedWord: "мама", attempts: 5, lettersUsed: "а"},
+			expected: []string{"Слово мама угадано целиком."},
+		},
+		{
+			name:     "wrong whole word",
+			previous: status{displayedWord: "_а_а", attempts: 5, lettersUsed: "а"},
+			current:  status{displayedWord: "_а_а", attempts: 4, lettersUsed: "а"},
+			expected: []string{"Слово названо неверно."},
+		},
+		{
+			name:     "undone letter",
+			previous: status{displayedWord: "_а_а", attempts: 4, lettersUsed: "ак"},
+			current:  status{displayedWord: "_а_а", attempts: 5, lettersUsed: "а"},
+			expected: []string{"Последняя попытка отменена."},
+		},
+		{
+			name:     "undone whole word",
+			previous: status{displayedWord: "мама", attempts: 5, lettersUsed: "а"},
+			current:  status{displayedWord: "_а_а", attempts: 5, lettersUsed: "а"},
+			expected: []string{"Последняя попытка отменена."},
+		},
+		{
+			name:     "unchanged",
+			previous: status{displayedWord: "_а_а", attempts: 5, lettersUsed: "а"},
+			current:  status{displayedWord: "_а_а", attempts: 5, lettersUsed: "а"},
+		},
+	}
+
+	announcements := []string{"открыта", "нет в слове", "угадано", "неверно", "отменена"}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+
+			gc, err := console.NewWithIO(console.Options{Lang: "ru", Accessible: true}, strings.NewReader(""), &out, false)
+			assert.NoError(t, err)
+
+			for _, st := range []status{tt.previous, tt.current} {
+				out.Reset()
+
+				letters := make(map[rune]struct{})
+				for _, letter := range st.lettersUsed {
+					letters[letter] = struct{}{}
+				}
+
+				gc.DisplaySessionStatus("пища", "лёгкая", frames.Frame{}, []rune(st.displayedWord), st.attempts, letters)
+			}
+
+			var lines []string
+
+			for _, line := range strings.Split(out.String(), "\n") {
+				for _, announcement := range announcements {
+					if strings.Contains(line, announcement) {
+						lines = append(lines, line)
+						break
+					}
+				}
+			}
+
+			assert.Equal(t, tt.expected, lines)
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package console
+
+import (
+	"context"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/frames"
+)
+
+// PlayAnimation проигрывает анимацию этапа игры. В терминале кадры перерисовываются на месте, иначе выводятся друг за другом.
+// Анимация прерывается отменой ctx или нажатием клавиши, после чего сразу выводится её последний кадр.
+// В Linux анимацию прерывает любая клавиша, на других платформах ввод построчный и её прерывает Enter.
+// В режиме уменьшения движения выводится только последний кадр, а в режиме специальных возможностей - его описание.
+func (gc *GameConsole) PlayAnimation(ctx context.Context, stage string, frs []frames.Frame, msDelay int) {
+	if len(frs) == 0 {
+		return
+	}
+
+	last := frs[len(frs)-1]
+
+	if gc.accessible {
+		gc.writeDescription(last, 2)
+		gc.flush()
+
+		return
+	}
+
+	if gc.reducedMotion {
+		gc.writeFrame(stage, last, 3)
+		gc.flush()
+
+		return
+	}
+
+	shown := gc.playFrames(ctx, stage, frs, msDelay)
+	if shown != len(frs)-1 {
+		gc.drawFrame(stage, last, frs[shown].Lines)
+	}
+
+	gc.write("", 2)
+	gc.flush()
+}
+
+// PlayTransition проигрывает переход между кадрами процесса, перерисовывая кадры на месте.
+// Переход проигрывается только в терминале и без уменьшения движения и прерывается так же, как PlayAnimation,
+// после чего поверх показанного кадра сразу выводится последний кадр перехода.
+func (gc *GameConsole) PlayTransition(ctx context.Context, frs []frames.Frame, msDelay int) {
+	if !gc.terminal || gc.reducedMotion || len(frs) == 0 {
+		return
+	}
+
+	shown := gc.playFrames(ctx, "process", frs, msDelay)
+	if shown != len(frs)-1 {
+		gc.drawFrame("process", frs[len(frs)-1], frs[shown].Lines)
+	}
+
+	gc.write("", 1)
+	gc.flush()
+}
+
+// playFrames выводит кадры этапа stage, выдерживая после каждого его задержку или msDelay, если она не задана,
+// ускоренную в gc.speed раз, пока кадры не закончатся, не будет отменён ctx или пользователь не нажмёт клавишу.
+// Возвращает номер последнего выведенного кадра.
+func (gc *GameConsole) playFrames(ctx context.Context, stage string, frs []frames.Frame, msDelay int) int {
+	ctx, cancel := context.WithCancel(ctx)
+
+	stop, anyKey := gc.listenSkip(ctx, cancel)
+	defer stop()
+
+	switch {
+	case !gc.interactive || !gc.terminal:
+	case anyKey:
+		gc.write(gc.msg.skipAnimation, 2)
+	default:
+		gc.write(gc.msg.skipAnimationLine, 2)
+	}
+
+	var previous []string
+
+	for i, fr := range frs {
+		gc.drawFrame(stage, fr, previous)
+		gc.flush()
+
+		previous = fr.Lines
+
+		delay := fr.MsDelay
+		if delay == 0 {
+			delay = msDelay
+		}
+
+		if !sleep(ctx, time.Duration(float64(delay)/gc.speed*float64(time.Millisecond))) {
+			return i
+		}
+	}
+
+	return len(frs) - 1
+}
+
+// drawFrame выводит кадр этапа stage. В терминале кадр выводится поверх предыдущего кадра previous,
+// иначе - после него, отделённый пустыми строками.
+func (gc *GameConsole) drawFrame(stage string, fr frames.Frame, previous []string) {
+	if !gc.terminal {
+		gc.writeFrame(stage, fr, 3)
+		return
+	}
+
+	if len(previous) != 0 {
+		gc.writef(0, "\x1b[%dA", len(previous))
+	}
+
+	for _, line := range fr.Lines {
+		gc.write("\x1b[2K"+gc.renderLine(line, gc.colors[stage]), 1)
+	}
+}
+
+// sleep ожидает в течение d и возвращает false, если ожидание прервано отменой ctx.
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,82 @@
+package console_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/frames"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/console"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlaySkip(t *testing.T) {
+	frs := []frames.Frame{
+		{Lines: []string{"первый"}},
+		{Lines: []string{"второй"}},
+		{Lines: []string{"последний"}},
+	}
+
+	tests := []struct {
+		name string
+		play func(gc *console.GameConsole)
+	}{
+		{
+			name: "animation",
+			play: func(gc *console.GameConsole) { gc.PlayAnimation(context.Background(), "victory", frs, 10_000) },
+		},
+		{
+			name: "transition",
+			play: func(gc *console.GameConsole) { gc.PlayTransition(context.Background(), frs, 10_000) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+
+			gc, err := console.NewWithIO(console.Options{Lang: "ru", Color: "never"}, strings.NewReader("x"), &out, true)
+			assert.NoError(t, err)
+
+			start := time.Now()
+			tt.play(gc)
+
+			assert.Less(t, time.Since(start), 5*time.Second)
+			assert.NotContains(t, out.String(), "второй")
+			assert.True(t, strings.HasSuffix(strings.TrimRight(out.String(), "\n"), "последний"), out.String())
+		})
+	}
+}
+
+func TestPlayTransitionNotTerminal(t *testing.T) {
+	var out bytes.Buffer
+
+	gc, err := console.NewWithIO(console.Options{Lang: "ru", Color: "never"}, strings.NewReader(""), &out, false)
+	assert.NoError(t, err)
+
+	gc.PlayTransition(context.Background(), []frames.Frame{{Lines: []string{"кадр"}}}, 10_000)
+	assert.Empty(t, out.String())
+}
+
+func TestPlayAnimationSpeed(t *testing.T) {
+	var out bytes.Buffer
+
+	gc, err := console.NewWithIO(console.Options{Lang: "ru", Color: "never"}, strings.NewReader(""), &out, false)
+	assert.NoError(t, err)
+
+	gc.SetSpeed(1000)
+
+	frs := []frames.Frame{
+		{Lines: []string{"первый"}, MsDelay: 10_000},
+		{Lines: []string{"последний"}},
+	}
+
+	start := time.Now()
+	gc.PlayAnimation(context.Background(), "victory", frs, 10_000)
+
+	assert.Less(t, time.Since(start), 5*time.Second)
+	assert.Contains(t, out.String(), "первый")
+	assert.Contains(t, out.String(), "последний")
+}
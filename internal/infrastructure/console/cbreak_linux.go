@@ -0,0 +1,41 @@
+package console
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// enableCbreak переводит терминал в посимвольный режим без эха, чтобы нажатие любой клавиши было доступно сразу,
+// и возвращает функцию, восстанавливающую прежний режим, и true. Если режим изменить не удалось, ввод остаётся
+// построчным, как на других платформах, и возвращается false.
+func enableCbreak(f *os.File) (restore func(), ok bool) {
+	var old syscall.Termios
+
+	if ioctl(f.Fd(), syscall.TCGETS, &old) != nil {
+		return func() {}, false
+	}
+
+	cbreak := old
+	cbreak.Lflag &^= syscall.ICANON | syscall.ECHO
+	cbreak.Cc[syscall.VMIN] = 1
+	cbreak.Cc[syscall.VTIME] = 0
+
+	if ioctl(f.Fd(), syscall.TCSETS, &cbreak) != nil {
+		return func() {}, false
+	}
+
+	return func() {
+		_ = ioctl(f.Fd(), syscall.TCSETS, &old)
+	}, true
+}
+
+// ioctl выполняет запрос req к терминалу с дескриптором fd.
+func ioctl(fd, req uintptr, t *syscall.Termios) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, req, uintptr(unsafe.Pointer(t)))
+	if errno != 0 {
+		return errno
+	}
+
+	return nil
+}
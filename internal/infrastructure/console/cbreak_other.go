@@ -0,0 +1,11 @@
+//go:build !linux
+
+package console
+
+import "os"
+
+// enableCbreak не меняет режим терминала и возвращает false: на этой платформе ввод остаётся построчным,
+// поэтому анимация прерывается нажатием Enter, о чём сообщает приглашение пропустить анимацию.
+func enableCbreak(_ *os.File) (restore func(), ok bool) {
+	return func() {}, false
+}
@@ -0,0 +1,95 @@
+package console
+
+import (
+	"os"
+	"strings"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/frames"
+)
+
+// ansiReset - последовательность ANSI, сбрасывающая оформление.
+const ansiReset = "\x1b[0m"
+
+// ansiStyles - словарь, сопоставляющий названиям цветов и начертаний последовательности ANSI.
+var ansiStyles = map[string]string{
+	"black":     "\x1b[30m",
+	"red":       "\x1b[31m",
+	"green":     "\x1b[32m",
+	"yellow":    "\x1b[33m",
+	"blue":      "\x1b[34m",
+	"magenta":   "\x1b[35m",
+	"cyan":      "\x1b[36m",
+	"white":     "\x1b[37m",
+	"bold":      "\x1b[1m",
+	"dim":       "\x1b[2m",
+	"italic":    "\x1b[3m",
+	"underline": "\x1b[4m",
+	"reverse":   "\x1b[7m",
+}
+
+// isColorEnabled определяет, нужно ли оформлять вывод цветами, по режиму цветного вывода.
+// В режиме auto цвета отключаются, если задана непустая переменная окружения NO_COLOR или вывод идёт не в терминал.
+func isColorEnabled(mode string, terminal bool) bool {
+	switch mode {
+	case "always":
+		return true
+	case "never":
+		return false
+	}
+
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+
+	return terminal
+}
+
+// isTerminal возвращает true, если поток является файлом терминала, иначе false.
+func isTerminal(stream any) bool {
+	f, ok := stream.(*os.File)
+	if !ok {
+		return false
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// style оформляет текст перечисленными стилями. Неизвестные стили пропускаются.
+// Если цвета отключены, текст возвращается без оформления.
+func (gc *GameConsole) style(text string, styles ...string) string {
+	if !gc.color || text == "" {
+		return text
+	}
+
+	var sb strings.Builder
+
+	for _, s := range styles {
+		sb.WriteString(ansiStyles[s])
+	}
+
+	if sb.Len() == 0 {
+		return text
+	}
+
+	sb.WriteString(text)
+	sb.WriteString(ansiReset)
+
+	return sb.String()
+}
+
+// renderLine преобразует строку кадра с разметкой стилей в текст для вывода,
+// окрашивая фрагменты без собственного цвета в цвет color.
+func (gc *GameConsole) renderLine(line, color string) string {
+	var sb strings.Builder
+
+	for _, segment := range frames.ParseMarkup(line) {
+		sb.WriteString(gc.style(segment.Text, append([]string{color}, segment.Styles...)...))
+	}
+
+	return sb.String()
+}
@@ -2,91 +2,268 @@ package console
 
 import (
 	"bufio"
+	"context"
 	"fmt"
+	"io"
+	"maps"
 	"os"
+	"slices"
+	"sort"
 	"strings"
-	"time"
 	"unicode"
 
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/conditions"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/frames"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/replay"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/theme"
 )
 
-const (
-	letterInputMessage       = "Введите букву (? для подсказки): "
-	border                   = "----------------------------------------------------------------------------------------"
-	hintForm                 = "Подсказка: %s"
-	categoryForm             = "Категория: %s"
-	difficultyForm           = "Уровень сложности: %s"
-	attemptsForm             = "Доступно попыток: %v"
-	categoryInputMessage     = "Выберите категорию (пропустите для случайного выбора):"
-	invalidCategoryMessage   = "Категории не существует. Пожалуйста, выберите одну из представленных категорий"
-	difficultyInputMessage   = "Выберите уровень сложности (пропустите для случайного выбора):"
-	invalidDifficultyMessage = "Уровня сложности не существует. Пожалуйста, выберите один из представленных уровней сложности"
-	lettersUsedMessage       = "Использованные буквы:"
-)
+const border = "----------------------------------------------------------------------------------------"
 
 // GameConsole реализует игровую консоль, с которой взаимодействует пользователь.
+// Ввод читается посимвольно в отдельной горутине, чтобы анимацию можно было прервать нажатием клавиши.
+// Задержки кадров анимаций делятся на ускорение speed.
+// Отображаемое слово и использованные буквы предыдущего вывода статуса хранятся, чтобы выделять и объявлять
+// только что открытые буквы. Если ввод не набирается пользователем, а воспроизводится, он выводится на экран.
 type GameConsole struct {
-	reader bufio.Reader
-	writer bufio.Writer
+	ctx           context.Context
+	keys          <-chan inputKey
+	echo          bool
+	writer        bufio.Writer
+	msg           messages
+	interactive   bool
+	tty           *os.File
+	terminal      bool
+	color         bool
+	reducedMotion bool
+	accessible    bool
+	practice      bool
+	speed         float64
+	colors        map[string]string
+	displayedWord []rune
+	attempts      int
+	lettersUsed   map[rune]struct{}
 }
 
-// New возвращает указатель на инициализированную структуру GameConsole, использующую стандарнтые потоки ввода-вывода.
-func New() *GameConsole {
-	return &GameConsole{
-		reader: *bufio.NewReader(os.Stdin),
-		writer: *bufio.NewWriter(os.Stdout),
-	}
+// Options хранит настройки вывода консоли: язык сообщений, режим цветного вывода,
+// признак уменьшения движения, при котором анимации не проигрываются, и признак режима специальных возможностей,
+// в котором кадры заменяются текстовыми описаниями, а вывод не содержит цветов и анимаций,
+// и признак тренировочной игры, в которой приглашение ко вводу напоминает о команде отмены попытки.
+// Input и Output задают потоки ввода и вывода, по-умолчанию стандартные.
+type Options struct {
+	Lang          string
+	Color         string
+	ReducedMotion bool
+	Accessible    bool
+	Practice      bool
+	Input         io.Reader
+	Output        io.Writer
 }
 
-// ChooseConditions возвращает категорию и уровня сложности.
-func (gc *GameConsole) ChooseConditions(
-	cts conditions.Categories,
-	dfs conditions.Difficulties,
-	randomSelectionCommand string,
-) (category, difficulty string, err error) {
-	category, err = gc.chooseCategory(cts, randomSelectionCommand)
+// New возвращает указатель на инициализированную структуру GameConsole, использующую потоки ввода-вывода из настроек
+// и сообщения на указанном языке.
+func New(opts Options) (*GameConsole, error) {
+	in := opts.Input
+	if in == nil {
+		in = os.Stdin
+	}
+
+	out := output(opts)
+
+	gc, err := newGameConsole(opts, readKeys(in), isTerminal(in), out, isTerminal(out))
 	if err != nil {
-		return "", "", fmt.Errorf("can`t choose category: %w", err)
+		return nil, err
 	}
 
-	difficulty, err = gc.chooseDifficulty(dfs, randomSelectionCommand)
+	if gc.interactive {
+		gc.tty, _ = in.(*os.File)
+	}
+
+	return gc, nil
+}
+
+// NewPlayback возвращает указатель на инициализированную структуру GameConsole, ввод которой воспроизводится
+// из записи игры: строки подаются с записанными промежутками, ускоренными в speed раз, и выводятся на экран.
+// Анимации проигрываются с тем же ускорением.
+func NewPlayback(opts Options, inputs []replay.Input, speed float64) (*GameConsole, error) {
+	out := output(opts)
+
+	gc, err := newGameConsole(opts, playKeys(inputs, speed), false, out, isTerminal(out))
 	if err != nil {
-		return "", "", fmt.Errorf("can`t choose difficulty: %w", err)
+		return nil, err
 	}
 
-	return category, difficulty, nil
+	gc.echo = true
+	gc.speed = speed
+
+	return gc, nil
 }
 
-// Enter принимает ввод буквы без учёта регистра.
-func (gc *GameConsole) Enter() (rune, error) {
-	var lineRemainder string
+// output возвращает поток вывода из настроек или стандартный вывод, если поток не задан.
+func output(opts Options) io.Writer {
+	if opts.Output == nil {
+		return os.Stdout
+	}
+
+	return opts.Output
+}
+
+// newGameConsole возвращает указатель на инициализированную структуру GameConsole, читающую ввод из keys
+// и пишущую вывод в out. Признаки interactive и terminal сообщают, набирается ли ввод в терминале
+// и выводится ли вывод в терминал.
+func newGameConsole(opts Options, keys <-chan inputKey, interactive bool, out io.Writer, terminal bool) (*GameConsole, error) {
+	msg, ok := locales[opts.Lang]
+	if !ok {
+		return nil, fmt.Errorf("unsupported language %q", opts.Lang)
+	}
+
+	return &GameConsole{
+		ctx:           context.Background(),
+		keys:          keys,
+		writer:        *bufio.NewWriter(out),
+		msg:           msg,
+		interactive:   interactive,
+		terminal:      terminal,
+		color:         !opts.Accessible && isColorEnabled(opts.Color, terminal),
+		reducedMotion: opts.ReducedMotion || opts.Accessible,
+		accessible:    opts.Accessible,
+		practice:      opts.Practice,
+		speed:         1,
+	}, nil
+}
+
+// Enter принимает ввод буквы или слова целиком без учёта регистра. Строка без пробелов по краям должна состоять
+// из видимых символов: буквы, знака вопроса, символа клавиши, который может оказаться буквой в другой раскладке,
+// или слова. Остальные строки запрашиваются снова.
+func (gc *GameConsole) Enter() (string, error) {
+	prompt := gc.msg.letterInput
+	if gc.practice {
+		prompt = gc.msg.practiceInput
+	}
 
 	for {
-		gc.print(letterInputMessage, 0)
+		gc.print(prompt, 0)
 
-		r, _, err := gc.reader.ReadRune()
+		line, err := gc.nextLine()
 		if err != nil {
-			return ' ', fmt.Errorf("can`t read rune: %w", err)
+			return "", fmt.Errorf("can`t read line: %w", err)
 		}
 
-		if unicode.IsLetter(r) || r == '?' {
-			lineRemainder, err = gc.reader.ReadString('\n') // Очищаем буфер
-			if err != nil {
-				return ' ', fmt.Errorf("can`t read rest of line: %w", err)
-			}
-
-			if lineRemainder == "\n" {
-				return unicode.ToLower(r), nil
-			}
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.ContainsFunc(line, func(r rune) bool { return !unicode.IsGraphic(r) }) {
+			return strings.ToLower(line), nil
 		}
 	}
 }
 
-// DisplayHint выводит передаваемую подсказку.
-func (gc *GameConsole) DisplayHint(hint string) {
-	gc.printf(1, hintForm, hint)
+// ConfirmLayout предлагает заменить символ или слово typed, набранные в другой раскладке, на converted
+// и возвращает true, если пользователь согласился. Пустой ввод считается согласием.
+func (gc *GameConsole) ConfirmLayout(typed, converted string) (bool, error) {
+	gc.printf(0, gc.msg.layoutConfirm, typed, converted)
+
+	line, err := gc.nextLine()
+	if err != nil {
+		return false, fmt.Errorf("can`t read line: %w", err)
+	}
+
+	return gc.isYes(line, true), nil
+}
+
+// ConfirmUndo в тренировочной игре предлагает отменить попытку, завершившую игру,
+// и возвращает true, если пользователь согласился. Пустой ввод считается отказом.
+func (gc *GameConsole) ConfirmUndo() (bool, error) {
+	gc.print(gc.msg.undoConfirm, 0)
+
+	line, err := gc.nextLine()
+	if err != nil {
+		return false, fmt.Errorf("can`t read line: %w", err)
+	}
+
+	return gc.isYes(line, false), nil
+}
+
+// DisplayLayoutConverted сообщает, что символ или слово typed, набранные в другой раскладке, заменены на converted.
+func (gc *GameConsole) DisplayLayoutConverted(typed, converted string) {
+	gc.printf(1, gc.msg.layoutConverted, typed, converted)
+}
+
+// DisplayHint выводит подсказку с номером number из total. Номер выводится, только если подсказок несколько.
+func (gc *GameConsole) DisplayHint(hint string, number, total int) {
+	if total > 1 {
+		gc.printf(1, gc.msg.hintNumberForm, number, total, hint)
+		return
+	}
+
+	gc.printf(1, gc.msg.hintForm, hint)
+}
+
+// SetContext задаёт контекст, отмена которого прерывает ожидание ввода.
+func (gc *GameConsole) SetContext(ctx context.Context) {
+	gc.ctx = ctx
+}
+
+// ConfirmSave сообщает, что игра прервана, и спрашивает, сохранить ли партию, чтобы продолжить её позже.
+// Ответ читается независимо от контекста консоли. Пустой ввод считается согласием.
+func (gc *GameConsole) ConfirmSave() (bool, error) {
+	gc.print("", 1)
+	gc.print(gc.msg.saveConfirm, 0)
+
+	line, err := gc.lineUntil(context.Background())
+	if err != nil {
+		return false, fmt.Errorf("can`t read line: %w", err)
+	}
+
+	return gc.isYes(line, true), nil
+}
+
+// isYes возвращает true, если ответ line без учёта регистра и пробелов по краям означает согласие, иначе false.
+// Пустой ответ означает согласие, если emptyYes равен true.
+func (gc *GameConsole) isYes(line string, emptyYes bool) bool {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return emptyYes
+	}
+
+	return slices.Contains(gc.msg.yes, strings.ToLower(line))
+}
+
+// DisplaySuspended сообщает, что прерванная партия сохранена и её можно продолжить при следующем запуске.
+func (gc *GameConsole) DisplaySuspended() {
+	gc.print(gc.msg.suspended, 1)
+}
+
+// ConfirmResume предлагает продолжить прерванную партию указанных категории и уровня сложности
+// и возвращает true, если пользователь согласился. Пустой ввод считается согласием.
+func (gc *GameConsole) ConfirmResume(category, difficulty string) (bool, error) {
+	gc.printf(0, gc.msg.resumeConfirm, gc.categoryName(category), difficulty)
+
+	line, err := gc.nextLine()
+	if err != nil {
+		return false, fmt.Errorf("can`t read line: %w", err)
+	}
+
+	return gc.isYes(line, true), nil
+}
+
+// DisplayInterrupted сообщает, что игра прервана.
+func (gc *GameConsole) DisplayInterrupted() {
+	gc.print("", 1)
+	gc.print(gc.msg.interrupted, 1)
+}
+
+// Flush выбрасывает накопленный вывод.
+func (gc *GameConsole) Flush() {
+	gc.flush()
+}
+
+// DisplayReplaySaved сообщает путь к сохранённой записи игры.
+func (gc *GameConsole) DisplayReplaySaved(path string) {
+	gc.printf(1, gc.msg.replayForm, path)
+}
+
+// DisplayNothingToUndo сообщает, что отменять нечего: ещё не сделано ни одной попытки.
+func (gc *GameConsole) DisplayNothingToUndo() {
+	gc.print(gc.msg.nothingToUndo, 1)
 }
 
 // DisplaySessionStatus выводит статус сессии.
@@ -97,114 +274,140 @@ func (gc *GameConsole) DisplaySessionStatus(
 	attempts int,
 	lettersUsed map[rune]struct{},
 ) {
-	gc.write(border, 2)
-	gc.writef(1, categoryForm, category)
-	gc.writef(2, difficultyForm, difficulty)
-	gc.writeFrame(fr, 2)
-	gc.writeLettersUsed(lettersUsed, 1)
-	gc.writef(1, attemptsForm, attempts)
-	gc.writeDisplayedWord(displayedWord, 2)
-	gc.flush()
-}
-
-// PlayAnimation проигрывает анимацию.
-func (gc *GameConsole) PlayAnimation(frs []frames.Frame, msDelay int) {
-	for _, fr := range frs {
-		gc.writeFrame(fr, 3)
+	if gc.accessible {
+		gc.displayAccessibleStatus(category, difficulty, fr, displayedWord, attempts, lettersUsed)
+	} else {
+		gc.write(border, 2)
+		gc.writef(1, gc.msg.categoryForm, gc.categoryName(category))
+		gc.writef(2, gc.msg.difficultyForm, difficulty)
+		gc.writeFrame("process", fr, 2)
+		gc.writeLettersUsed(lettersUsed, displayedWord, 1)
+		gc.writef(1, gc.msg.attemptsForm, attempts)
+		gc.writeDisplayedWord(displayedWord, 2)
 		gc.flush()
-		time.Sleep(time.Duration(msDelay) * time.Millisecond)
 	}
-}
 
-// chooseCategory отображает категории и возвращает выбор.
-func (gc *GameConsole) chooseCategory(cts conditions.Categories, randomSelectionCommand string) (string, error) {
-	gc.write(categoryInputMessage, 0)
+	gc.displayedWord = slices.Clone(displayedWord)
+	gc.attempts = attempts
+	gc.lettersUsed = maps.Clone(lettersUsed)
+}
 
-	for ct := range cts {
-		gc.write(" "+ct, 0)
+// categoryName возвращает категорию слова или обозначение слова, заданного игроком без категории.
+func (gc *GameConsole) categoryName(category string) string {
+	if category == "" {
+		return gc.msg.customCategory
 	}
 
-	gc.write("", 1)
-	gc.flush()
+	return category
+}
 
-	condition, err := gc.enterCategory(cts, randomSelectionCommand)
-	if err != nil {
-		return "", fmt.Errorf("can`t enter category: %w", err)
-	}
+// DisplaySummary выводит итог игры, которым можно поделиться.
+func (gc *GameConsole) DisplaySummary(summary string) {
+	gc.write(gc.msg.summary, 1)
+	gc.write(summary, 2)
+	gc.flush()
+}
 
-	return condition, err
+// DisplayDailyPlayed сообщает, что испытание дня уже сыграно, и выводит сохранённый итог.
+func (gc *GameConsole) DisplayDailyPlayed(summary string) {
+	gc.write(gc.msg.dailyPlayed, 2)
+	gc.DisplaySummary(summary)
 }
 
-// chooseCategory() отображает уровни сложности и возвращает выбор.
-func (gc *GameConsole) chooseDifficulty(dfs conditions.Difficulties, randomSelectionCommand string) (string, error) {
-	gc.write(difficultyInputMessage, 0)
+// DisplaySeed выводит зерно генератора случайных чисел, с которым можно воспроизвести игру.
+func (gc *GameConsole) DisplaySeed(seed uint64) {
+	gc.printf(1, gc.msg.seedForm, seed)
+}
 
-	for df := range dfs {
-		gc.write(" "+df, 0)
+// ChooseDifficulty отображает уровни сложности и возвращает выбор.
+func (gc *GameConsole) ChooseDifficulty(dfs conditions.Difficulties, randomSelectionCommand string) (string, error) {
+	difficulty, err := chooseOption(gc, gc.msg.difficultyInput, gc.msg.invalidDifficulty, dfs, randomSelectionCommand)
+	if err != nil {
+		return "", fmt.Errorf("can`t enter difficulty: %w", err)
 	}
 
-	gc.write("", 1)
-	gc.flush()
+	return difficulty, nil
+}
 
-	condition, err := gc.enterDifficulty(dfs, randomSelectionCommand)
+// ChooseTheme отображает темы оформления и возвращает выбор.
+func (gc *GameConsole) ChooseTheme(ths theme.Themes, randomSelectionCommand string) (string, error) {
+	name, err := chooseOption(gc, gc.msg.themeInput, gc.msg.invalidTheme, ths, randomSelectionCommand)
 	if err != nil {
-		return "", fmt.Errorf("can`t enter difficulty: %w", err)
+		return "", fmt.Errorf("can`t enter theme: %w", err)
 	}
 
-	return condition, err
+	return name, nil
 }
 
-// enterCategory принимает ввод категории и возвращает её.
-func (gc *GameConsole) enterCategory(cts conditions.Categories, randomSelectionCommand string) (string, error) {
-	var (
-		category string
-		err      error
-	)
+// SetColors задаёт цвета кадров этапов игры. Этапы без цвета выводятся без оформления.
+func (gc *GameConsole) SetColors(colors map[string]string) {
+	gc.colors = colors
+}
 
-	for {
-		category, err = gc.readLine()
-		if err != nil {
-			return "", fmt.Errorf("can`t read category: %w", err)
-		}
+// DisplayMessage выводит сообщение.
+func (gc *GameConsole) DisplayMessage(message string) {
+	gc.print(message, 2)
+}
 
-		_, ok := cts[category]
-		if ok || category == randomSelectionCommand {
-			break
-		}
+// DisplayExplanation выводит пояснение к загаданному слову и его источник. Пустые значения не выводятся.
+func (gc *GameConsole) DisplayExplanation(explanation, source string) {
+	if explanation != "" {
+		gc.print(explanation, 1)
+	}
 
-		gc.print(invalidCategoryMessage, 1)
+	if source != "" {
+		gc.printf(1, gc.msg.sourceForm, source)
 	}
 
-	return category, nil
+	gc.print("", 1)
 }
 
-// enterDifficulty принимает ввод сложности и возвращает его.
-func (gc *GameConsole) enterDifficulty(dfs conditions.Difficulties, randomSelectionCommand string) (string, error) {
-	var (
-		difficulty string
-		err        error
-	)
+// chooseOption отображает упорядоченные варианты выбора и принимает ввод одного из них или команды случайного выбора.
+func chooseOption[T any](
+	gc *GameConsole,
+	inputMessage, invalidMessage string,
+	options map[string]T,
+	randomSelectionCommand string,
+) (string, error) {
+	names := make([]string, 0, len(options))
+	for name := range options {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	gc.writeOptions(inputMessage, names)
 
 	for {
-		difficulty, err = gc.readLine()
+		option, err := gc.readLine()
 		if err != nil {
-			return "", fmt.Errorf("can`t read difficulty: %w", err)
+			return "", fmt.Errorf("can`t read line: %w", err)
 		}
 
-		_, ok := dfs[difficulty]
-		if ok || difficulty == randomSelectionCommand {
-			break
+		_, ok := options[option]
+		if ok || option == randomSelectionCommand {
+			return option, nil
 		}
 
-		gc.print(invalidDifficultyMessage, 1)
+		gc.print(invalidMessage, 1)
+	}
+}
+
+// writeOptions выводит приглашение к вводу и перечисляет после него варианты выбора.
+func (gc *GameConsole) writeOptions(inputMessage string, names []string) {
+	gc.write(inputMessage, 0)
+
+	for _, name := range names {
+		gc.write(" "+name, 0)
 	}
 
-	return difficulty, nil
+	gc.write("", 1)
+	gc.flush()
 }
 
 // readLine читает строки без учёта регистра.
 func (gc *GameConsole) readLine() (string, error) {
-	word, err := gc.reader.ReadString('\n')
+	word, err := gc.nextLine()
 	if err != nil {
 		return "", fmt.Errorf("can`t read string: %w", err)
 	}
@@ -248,30 +451,52 @@ func (gc *GameConsole) printf(indents int, format string, a ...any) {
 	gc.flush()
 }
 
-// writeFrame пишет линии кадра fr в gc.writer.
-func (gc *GameConsole) writeFrame(fr frames.Frame, indents int) {
-	for _, line := range fr {
-		gc.write(line, 1)
+// writeFrame пишет линии кадра fr этапа stage в gc.writer, окрашивая их в цвет этапа и применяя разметку стилей.
+func (gc *GameConsole) writeFrame(stage string, fr frames.Frame, indents int) {
+	for _, line := range fr.Lines {
+		gc.write(gc.renderLine(line, gc.colors[stage]), 1)
 	}
 
 	gc.write("", indents)
 }
 
-// writeLettersUsed пишет использованные буквы gc.writer.
-func (gc *GameConsole) writeLettersUsed(lettersUsed map[rune]struct{}, indents int) {
-	gc.write(lettersUsedMessage, 0)
+// writeLettersUsed пишет упорядоченные использованные буквы в gc.writer, выделяя буквы, которых нет в слове.
+func (gc *GameConsole) writeLettersUsed(lettersUsed map[rune]struct{}, displayedWord []rune, indents int) {
+	gc.write(gc.msg.lettersUsed, 0)
 
-	for letter := range lettersUsed {
-		gc.write(" "+string(letter), 0)
+	for _, letter := range sortedLetters(lettersUsed) {
+		if slices.Contains(displayedWord, letter) {
+			gc.write(" "+string(letter), 0)
+		} else {
+			gc.write(" "+gc.style(string(letter), "red"), 0)
+		}
 	}
 
 	gc.write("", indents)
 }
 
-// writeDisplayedWord пишет символы отображаемого слова в gc.writer.
+// sortedLetters возвращает упорядоченные буквы множества.
+func sortedLetters(set map[rune]struct{}) []rune {
+	letters := make([]rune, 0, len(set))
+	for letter := range set {
+		letters = append(letters, letter)
+	}
+
+	slices.Sort(letters)
+
+	return letters
+}
+
+// writeDisplayedWord пишет символы отображаемого слова в gc.writer, выделяя буквы, открытые после предыдущего вывода.
 func (gc *GameConsole) writeDisplayedWord(displayedWord []rune, indents int) {
-	for _, letter := range displayedWord {
-		gc.write(string(letter), 0)
+	hasPrevious := len(gc.displayedWord) == len(displayedWord)
+
+	for i, letter := range displayedWord {
+		if hasPrevious && gc.displayedWord[i] != letter {
+			gc.write(gc.style(string(letter), "bold", "green"), 0)
+		} else {
+			gc.write(string(letter), 0)
+		}
 	}
 
 	gc.write("", indents)
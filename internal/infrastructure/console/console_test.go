@@ -0,0 +1,48 @@
+package console_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/console"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfirm(t *testing.T) {
+	confirms := map[string]func(gc *console.GameConsole) (bool, error){
+		"layout": func(gc *console.GameConsole) (bool, error) { return gc.ConfirmLayout("r", "к") },
+		"undo":   (*console.GameConsole).ConfirmUndo,
+		"save":   (*console.GameConsole).ConfirmSave,
+		"resume": func(gc *console.GameConsole) (bool, error) { return gc.ConfirmResume("пища", "лёгкая") },
+	}
+
+	tests := []struct {
+		line     string
+		expected map[string]bool
+	}{
+		{line: "", expected: map[string]bool{"layout": true, "undo": false, "save": true, "resume": true}},
+		{line: "  \t", expected: map[string]bool{"layout": true, "undo": false, "save": true, "resume": true}},
+		{line: " Д ", expected: map[string]bool{"layout": true, "undo": true, "save": true, "resume": true}},
+		{line: "да\r", expected: map[string]bool{"layout": true, "undo": true, "save": true, "resume": true}},
+		{line: "\tYes ", expected: map[string]bool{"layout": true, "undo": true, "save": true, "resume": true}},
+		{line: " н ", expected: map[string]bool{"layout": false, "undo": false, "save": false, "resume": false}},
+		{line: "нет", expected: map[string]bool{"layout": false, "undo": false, "save": false, "resume": false}},
+	}
+
+	for _, tt := range tests {
+		for name, confirm := range confirms {
+			t.Run(name+" "+tt.line, func(t *testing.T) {
+				var out bytes.Buffer
+
+				gc, err := console.NewWithIO(console.Options{Lang: "ru"}, strings.NewReader(tt.line+"\n"), &out, false)
+				assert.NoError(t, err)
+
+				ok, err := confirm(gc)
+
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected[name], ok)
+			})
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package console
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/dictionary"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/session"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/theme"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/words"
+	"github.com/es-debug/backend-academy-2024-go-template/pkg/hangman"
+)
+
+// DisplayError выводит понятное пользователю сообщение об ошибке.
+func (gc *GameConsole) DisplayError(err error) {
+	gc.print(gc.errorMessage(err), 1)
+}
+
+// errorMessage возвращает сообщение на языке консоли для известных ошибок предметной области
+// с буквой, словом, условиями игры или темой, из-за которых они произошли, и общее сообщение с текстом ошибки для остальных.
+func (gc *GameConsole) errorMessage(err error) string {
+	var (
+		letterErr    *hangman.LetterError
+		wordErr      *hangman.WordError
+		unknownErr   *dictionary.WordError
+		conditionErr *words.ConditionError
+		themeErr     *theme.NameError
+	)
+
+	switch {
+	case errors.As(err, &letterErr) && errors.Is(err, hangman.ErrAlreadyGuessed):
+		return fmt.Sprintf(gc.msg.alreadyGuessed, letterErr.Letter)
+	case errors.As(err, &letterErr) && errors.Is(err, hangman.ErrInvalidLetter):
+		return fmt.Sprintf(gc.msg.invalidLetter, letterErr.Letter)
+	case errors.As(err, &wordErr) && errors.Is(err, hangman.ErrAlreadyGuessed):
+		return fmt.Sprintf(gc.msg.wordGuessed, wordErr.Word)
+	case errors.As(err, &wordErr) && errors.Is(err, hangman.ErrInvalidWord):
+		return fmt.Sprintf(gc.msg.invalidWord, wordErr.Word)
+	case errors.As(err, &unknownErr) && errors.Is(err, dictionary.ErrUnknownWord):
+		return fmt.Sprintf(gc.msg.unknownWord, unknownErr.Word)
+	case errors.Is(err, hangman.ErrGameOver):
+		return gc.msg.gameOver
+	case errors.As(err, &conditionErr) && errors.Is(err, words.ErrUnknownCategory):
+		return fmt.Sprintf(gc.msg.unknownCategory, conditionErr.Category)
+	case errors.As(err, &conditionErr) && errors.Is(err, words.ErrUnknownDifficulty):
+		return fmt.Sprintf(gc.msg.unknownDifficulty, conditionErr.Difficulty)
+	case errors.As(err, &conditionErr) && errors.Is(err, words.ErrEmptyWordList):
+		return fmt.Sprintf(gc.msg.emptyWordList, conditionErr.Category, conditionErr.Difficulty)
+	case errors.As(err, &conditionErr) && errors.Is(err, words.ErrNoMatches):
+		return fmt.Sprintf(gc.msg.noMatches, conditionErr.Difficulty)
+	case errors.As(err, &themeErr) && errors.Is(err, theme.ErrUnknownTheme):
+		return fmt.Sprintf(gc.msg.unknownTheme, themeErr.Name)
+	case errors.As(err, &themeErr) && errors.Is(err, session.ErrReplayMismatch):
+		return fmt.Sprintf(gc.msg.replayMismatch, themeErr.Name)
+	default:
+		return fmt.Sprintf(gc.msg.errorForm, err)
+	}
+}
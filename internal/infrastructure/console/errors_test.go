@@ -0,0 +1,90 @@
+package console_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/dictionary"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/session"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/theme"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/words"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/console"
+	"github.com/es-debug/backend-academy-2024-go-template/pkg/hangman"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisplayError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{
+			name:     "already guessed",
+			err:      &hangman.LetterError{Letter: 'а', Err: hangman.ErrAlreadyGuessed},
+			expected: "«а»",
+		},
+		{
+			name:     "word already guessed",
+			err:      &hangman.WordError{Word: "кит", Err: hangman.ErrAlreadyGuessed},
+			expected: "Слово «кит» уже было названо",
+		},
+		{
+			name:     "word not in dictionary",
+			err:      fmt.Errorf("can`t use dictionary: %w", &dictionary.WordError{Word: "кат", Err: dictionary.ErrUnknownWord}),
+			expected: "Слова «кат» нет в списке слов",
+		},
+		{
+			name:     "unknown category",
+			err:      fmt.Errorf("can`t configure: %w", &words.ConditionError{Category: "фрукты", Err: words.ErrUnknownCategory}),
+			expected: "Категории «фрукты» нет в словаре",
+		},
+		{
+			name:     "unknown difficulty",
+			err:      &words.ConditionError{Difficulty: "адская", Err: words.ErrUnknownDifficulty},
+			expected: "Уровня сложности «адская» нет",
+		},
+		{
+			name:     "empty word list",
+			err:      &words.ConditionError{Category: "пища", Difficulty: "лёгкая", Err: words.ErrEmptyWordList},
+			expected: "В словаре нет слов категории «пища» уровня сложности «лёгкая»",
+		},
+		{
+			name:     "no matches",
+			err:      &words.ConditionError{Difficulty: "трудная", Err: words.ErrNoMatches},
+			expected: "Нет слов уровня сложности «трудная»",
+		},
+		{
+			name:     "unknown theme",
+			err:      &theme.NameError{Name: "космос", Err: theme.ErrUnknownTheme},
+			expected: "Темы оформления «космос» нет",
+		},
+		{
+			name:     "replay mismatch",
+			err:      &theme.NameError{Name: "шарик", Err: fmt.Errorf("%w: frame index 9 is out of range", session.ErrReplayMismatch)},
+			expected: "кадрам темы «шарик»",
+		},
+		{
+			name:     "other",
+			err:      errors.New("disk is full"),
+			expected: "Ошибка: disk is full",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+
+			gc, err := console.NewWithIO(console.Options{Lang: "ru", Color: "never"}, strings.NewReader(""), &out, false)
+			assert.NoError(t, err)
+
+			gc.DisplayError(tt.err)
+			gc.Flush()
+
+			assert.Contains(t, out.String(), tt.expected)
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package console
+
+import "io"
+
+// NewWithIO возвращает указатель на GameConsole, читающую ввод из in и пишущую вывод в out.
+// Признак terminal задаёт, идут ли ввод и вывод через терминал.
+func NewWithIO(opts Options, in io.Reader, out io.Writer, terminal bool) (*GameConsole, error) {
+	return newGameConsole(opts, readKeys(in), terminal, out, terminal)
+}
+
+// SetSpeed задаёт ускорение анимаций, как при воспроизведении записи игры.
+func (gc *GameConsole) SetSpeed(speed float64) {
+	gc.speed = speed
+}
@@ -0,0 +1,153 @@
+package console
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/conditions"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/schema"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/words"
+)
+
+// filterCommand - команда, которую можно ввести вместо категории, чтобы настроить фильтр слов.
+const filterCommand = "+"
+
+// ChooseWords отображает категории и возвращает фильтр слов по выбору пользователя: одну или несколько категорий
+// через запятую, пустой фильтр при вводе команды случайного выбора или фильтр, настроенный после ввода команды фильтров.
+// Возвращаемый признак равен true, если пользователь решил исключить уже встречавшиеся слова.
+func (gc *GameConsole) ChooseWords(
+	cts conditions.Categories,
+	tags []string,
+	randomSelectionCommand string,
+) (words.Query, bool, error) {
+	gc.writeOptions(gc.msg.categoryInput, schema.SortedKeys(cts))
+
+	for {
+		line, err := gc.readLine()
+		if err != nil {
+			return words.Query{}, false, fmt.Errorf("can`t enter category: %w", err)
+		}
+
+		switch line {
+		case randomSelectionCommand:
+			return words.Query{}, false, nil
+		case filterCommand:
+			return gc.chooseFilter(cts, tags)
+		}
+
+		categories, ok := parseList(line, schema.SortedKeys(cts))
+		if ok {
+			return words.Query{Categories: categories}, false, nil
+		}
+
+		gc.print(gc.msg.invalidCategory, 1)
+	}
+}
+
+// chooseFilter последовательно запрашивает условия фильтра слов. Пропущенные условия не ограничивают выбор.
+func (gc *GameConsole) chooseFilter(cts conditions.Categories, tags []string) (words.Query, bool, error) {
+	var q words.Query
+
+	gc.writeOptions(gc.msg.filterCategories, schema.SortedKeys(cts))
+
+	err := gc.ask(gc.msg.invalidCategory, func(line string) bool {
+		var ok bool
+		q.Categories, ok = parseList(line, schema.SortedKeys(cts))
+
+		return ok
+	})
+	if err != nil {
+		return q, false, fmt.Errorf("can`t enter categories: %w", err)
+	}
+
+	if len(tags) != 0 {
+		gc.writeOptions(gc.msg.filterTags, tags)
+
+		err = gc.ask(gc.msg.invalidTag, func(line string) bool {
+			var ok bool
+			q.Tags, ok = parseList(line, tags)
+
+			return ok
+		})
+		if err != nil {
+			return q, false, fmt.Errorf("can`t enter tags: %w", err)
+		}
+	}
+
+	gc.print(gc.msg.filterLength, 0)
+
+	err = gc.ask(gc.msg.invalidValue, func(line string) bool {
+		var err error
+		q.MinLength, q.MaxLength, err = words.ParseLengthRange(line)
+
+		return err == nil
+	})
+	if err != nil {
+		return q, false, fmt.Errorf("can`t enter length: %w", err)
+	}
+
+	gc.print(gc.msg.filterDistinct, 0)
+
+	err = gc.ask(gc.msg.invalidValue, func(line string) bool {
+		n, err := strconv.Atoi(line)
+		if err != nil || n < 1 {
+			return false
+		}
+
+		q.MinDistinct = n
+
+		return true
+	})
+	if err != nil {
+		return q, false, fmt.Errorf("can`t enter distinct letters: %w", err)
+	}
+
+	gc.print(gc.msg.filterUnseen, 0)
+
+	line, err := gc.readLine()
+	if err != nil {
+		return q, false, fmt.Errorf("can`t enter exclusion of seen words: %w", err)
+	}
+
+	return q, slices.Contains(gc.msg.yes, strings.ToLower(line)), nil
+}
+
+// ask читает строки, пока parse не примет строку, выводя invalidMessage после каждой непринятой строки.
+// Пустая строка принимается без разбора и оставляет условие без ограничения.
+func (gc *GameConsole) ask(invalidMessage string, parse func(line string) bool) error {
+	for {
+		line, err := gc.readLine()
+		if err != nil {
+			return fmt.Errorf("can`t read line: %w", err)
+		}
+
+		if line == "" || parse(line) {
+			return nil
+		}
+
+		gc.print(invalidMessage, 1)
+	}
+}
+
+// parseList разбирает список значений через запятую и возвращает их в написании из options и true
+// или nil и false, если какого-либо значения нет среди options. Значения сравниваются без учёта регистра.
+func parseList(line string, options []string) ([]string, bool) {
+	var values []string
+
+	for _, item := range strings.Split(line, ",") {
+		i := slices.IndexFunc(options, func(option string) bool {
+			return strings.EqualFold(option, strings.TrimSpace(item))
+		})
+		if i == -1 {
+			return nil, false
+		}
+
+		if !slices.Contains(values, options[i]) {
+			values = append(values, options[i])
+		}
+	}
+
+	return values, true
+}
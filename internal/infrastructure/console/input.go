@@ -0,0 +1,134 @@
+package console
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/replay"
+)
+
+// inputKey хранит символ ввода или ошибку чтения.
+type inputKey struct {
+	r   rune
+	err error
+}
+
+// readKeys читает символы из r в отдельной горутине, чтобы ожидание ввода можно было совмещать с анимацией.
+// После ошибки чтения канал закрывается.
+func readKeys(r io.Reader) <-chan inputKey {
+	keys := make(chan inputKey)
+
+	go func() {
+		defer close(keys)
+
+		reader := bufio.NewReader(r)
+
+		for {
+			r, _, err := reader.ReadRune()
+			keys <- inputKey{r: r, err: err}
+
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	return keys
+}
+
+// playKeys подаёт символы строк записанного ввода в отдельной горутине, выдерживая между строками записанные
+// промежутки, ускоренные в speed раз. После последней строки канал закрывается.
+func playKeys(inputs []replay.Input, speed float64) <-chan inputKey {
+	keys := make(chan inputKey)
+
+	go func() {
+		defer close(keys)
+
+		var last int64
+
+		for _, in := range inputs {
+			time.Sleep(time.Duration(float64(in.Ms-last)/speed) * time.Millisecond)
+			last = in.Ms
+
+			for _, r := range in.Value + "\n" {
+				keys <- inputKey{r: r}
+			}
+		}
+	}()
+
+	return keys
+}
+
+// nextLine возвращает следующую строку ввода без перевода строки.
+// Отмена контекста консоли прерывает ожидание ввода и возвращает причину отмены.
+func (gc *GameConsole) nextLine() (string, error) {
+	return gc.lineUntil(gc.ctx)
+}
+
+// lineUntil возвращает следующую строку ввода без перевода строки или причину отмены ctx.
+func (gc *GameConsole) lineUntil(ctx context.Context) (string, error) {
+	var sb strings.Builder
+
+	for {
+		var (
+			key inputKey
+			ok  bool
+		)
+
+		select {
+		case key, ok = <-gc.keys:
+		case <-ctx.Done():
+			return "", context.Cause(ctx)
+		}
+
+		if !ok {
+			return "", io.EOF
+		}
+
+		if key.err != nil {
+			return "", key.err
+		}
+
+		if gc.echo {
+			gc.print(string(key.r), 0)
+		}
+
+		if key.r == '\n' {
+			return strings.TrimSuffix(sb.String(), "\r"), nil
+		}
+
+		sb.WriteRune(key.r)
+	}
+}
+
+// listenSkip в отдельной горутине ожидает нажатия клавиши и отменяет ctx, прерывая анимацию.
+// Нажатая клавиша не считается вводом. Клавиши ожидаются, только если ввод идёт из терминала: иначе строки
+// предназначены для игры. Возвращает функцию, которая завершает ожидание и восстанавливает режим терминала,
+// и true, если анимацию прерывает любая клавиша, или false, если ввод построчный и её прерывает только Enter.
+func (gc *GameConsole) listenSkip(ctx context.Context, cancel context.CancelFunc) (stop func(), anyKey bool) {
+	if !gc.interactive {
+		return cancel, false
+	}
+
+	restore, anyKey := enableCbreak(gc.tty)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		select {
+		case <-ctx.Done():
+		case <-gc.keys:
+			cancel()
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+		restore()
+	}, anyKey
+}
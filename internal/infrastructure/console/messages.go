@@ -0,0 +1,199 @@
+package console
+
+// messages хранит тексты сообщений консоли на одном языке.
+type messages struct {
+	letterInput       string
+	hintForm          string
+	categoryForm      string
+	difficultyForm    string
+	attemptsForm      string
+	categoryInput     string
+	invalidCategory   string
+	difficultyInput   string
+	invalidDifficulty string
+	themeInput        string
+	invalidTheme      string
+	lettersUsed       string
+	summary           string
+	dailyPlayed       string
+	seedForm          string
+	skipAnimation     string
+	skipAnimationLine string
+	pictureForm       string
+	noDescription     string
+	wordForm          string
+	positionForm      string
+	emptyPosition     string
+	revealForm        string
+	missForm          string
+	wordHitForm       string
+	wordMiss          string
+	undone            string
+	layoutConfirm     string
+	layoutConverted   string
+	yes               []string
+	practiceInput     string
+	nothingToUndo     string
+	undoConfirm       string
+	replayForm        string
+	alreadyGuessed    string
+	invalidLetter     string
+	gameOver          string
+	unknownCategory   string
+	unknownDifficulty string
+	emptyWordList     string
+	unknownTheme      string
+	replayMismatch    string
+	errorForm         string
+	saveConfirm       string
+	interrupted       string
+	suspended         string
+	resumeConfirm     string
+	hintNumberForm    string
+	sourceForm        string
+	filterCategories  string
+	filterTags        string
+	invalidTag        string
+	filterLength      string
+	filterDistinct    string
+	filterUnseen      string
+	invalidValue      string
+	noMatches         string
+	wordGuessed       string
+	invalidWord       string
+	unknownWord       string
+	customCategory    string
+}
+
+// DefaultLanguage - язык сообщений консоли по-умолчанию.
+const DefaultLanguage = "ru"
+
+// locales - словарь, сопоставляющий коду языка тексты сообщений консоли.
+var locales = map[string]messages{
+	"ru": {
+		letterInput:       "Введите букву или слово целиком (? для подсказки): ",
+		hintForm:          "Подсказка: %s",
+		categoryForm:      "Категория: %s",
+		difficultyForm:    "Уровень сложности: %s",
+		attemptsForm:      "Доступно попыток: %v",
+		categoryInput:     "Выберите категорию или несколько через запятую (пропустите для случайного выбора, + для фильтров):",
+		invalidCategory:   "Категории не существует. Пожалуйста, выберите одну из представленных категорий",
+		difficultyInput:   "Выберите уровень сложности (пропустите для случайного выбора):",
+		invalidDifficulty: "Уровня сложности не существует. Пожалуйста, выберите один из представленных уровней сложности",
+		themeInput:        "Выберите тему оформления (пропустите для случайного выбора):",
+		invalidTheme:      "Темы не существует. Пожалуйста, выберите одну из представленных тем",
+		lettersUsed:       "Использованные буквы:",
+		summary:           "Поделитесь результатом:",
+		dailyPlayed:       "Испытание дня уже сыграно. Возвращайтесь завтра!",
+		seedForm:          "Зерно игры: %d",
+		skipAnimation:     "(нажмите любую клавишу, чтобы пропустить)",
+		skipAnimationLine: "(нажмите Enter, чтобы пропустить)",
+		pictureForm:       "Рисунок: %s.",
+		noDescription:     "описание отсутствует",
+		wordForm:          "Букв в слове: %d. %s.",
+		positionForm:      "%d: %s",
+		emptyPosition:     "пусто",
+		revealForm:        "Буква %s открыта на позициях: %s.",
+		missForm:          "Буквы %s нет в слове.",
+		wordHitForm:       "Слово %s угадано целиком.",
+		wordMiss:          "Слово названо неверно.",
+		undone:            "Последняя попытка отменена.",
+		layoutConfirm:     "Похоже, включена другая раскладка. Ввести «%s» как «%s»? [Д/н]: ",
+		layoutConverted:   "Похоже, включена другая раскладка: «%s» введено как «%s»",
+		yes:               []string{"д", "да", "y", "yes", "l"},
+		practiceInput:     "Введите букву или слово целиком (? для подсказки, < для отмены попытки): ",
+		nothingToUndo:     "Отменять нечего: попыток ещё не было",
+		undoConfirm:       "Игра окончена. Отменить последнюю попытку? [д/Н]: ",
+		replayForm:        "Запись игры сохранена: %s",
+		alreadyGuessed:    "Буква «%c» уже была названа",
+		invalidLetter:     "«%c» — не буква",
+		gameOver:          "Игра уже окончена",
+		unknownCategory:   "Категории «%s» нет в словаре",
+		unknownDifficulty: "Уровня сложности «%s» нет",
+		emptyWordList:     "В словаре нет слов категории «%s» уровня сложности «%s»",
+		unknownTheme:      "Темы оформления «%s» нет",
+		replayMismatch:    "Запись игры не соответствует кадрам темы «%s»",
+		errorForm:         "Ошибка: %v",
+		saveConfirm:       "Игра прервана. Сохранить партию, чтобы продолжить её позже? [Д/н]: ",
+		interrupted:       "Игра прервана",
+		suspended:         "Партия сохранена, её можно будет продолжить при следующем запуске",
+		resumeConfirm:     "Есть прерванная партия: категория «%s», уровень сложности «%s». Продолжить её? [Д/н]: ",
+		hintNumberForm:    "Подсказка %d из %d: %s",
+		sourceForm:        "Источник: %s",
+		filterCategories:  "Категории через запятую (пропустите для всех):",
+		filterTags:        "Теги через запятую (пропустите для любых):",
+		invalidTag:        "Такого тега нет. Пожалуйста, выберите из представленных тегов",
+		filterLength:      "Длина слова, например 5-8, 5- или 6 (пропустите для любой): ",
+		filterDistinct:    "Наименьшее количество разных букв (пропустите для любого): ",
+		filterUnseen:      "Исключить уже встречавшиеся слова? [д/Н]: ",
+		invalidValue:      "Неверное значение, попробуйте ещё раз",
+		noMatches:         "Нет слов уровня сложности «%s», подходящих под выбранные условия",
+		wordGuessed:       "Слово «%s» уже было названо",
+		invalidWord:       "«%s» — не слово: в нём нет букв",
+		unknownWord:       "Слова «%s» нет в списке слов",
+		customCategory:    "слово игрока",
+	},
+	"en": {
+		letterInput:       "Enter a letter or the whole word (? for a hint): ",
+		hintForm:          "Hint: %s",
+		categoryForm:      "Category: %s",
+		difficultyForm:    "Difficulty: %s",
+		attemptsForm:      "Attempts left: %v",
+		categoryInput:     "Choose a category or several separated by commas (skip for a random choice, + for filters):",
+		invalidCategory:   "No such category. Please choose one of the listed categories",
+		difficultyInput:   "Choose a difficulty (skip for a random choice):",
+		invalidDifficulty: "No such difficulty. Please choose one of the listed difficulties",
+		themeInput:        "Choose a theme (skip for a random choice):",
+		invalidTheme:      "No such theme. Please choose one of the listed themes",
+		lettersUsed:       "Letters used:",
+		summary:           "Share your result:",
+		dailyPlayed:       "Today's challenge has already been played. Come back tomorrow!",
+		seedForm:          "Game seed: %d",
+		skipAnimation:     "(press any key to skip)",
+		skipAnimationLine: "(press Enter to skip)",
+		pictureForm:       "Picture: %s.",
+		noDescription:     "no description",
+		wordForm:          "Letters in the word: %d. %s.",
+		positionForm:      "%d: %s",
+		emptyPosition:     "blank",
+		revealForm:        "Letter %s is revealed at positions: %s.",
+		missForm:          "There is no letter %s in the word.",
+		wordHitForm:       "The whole word %s is guessed.",
+		wordMiss:          "The named word is wrong.",
+		undone:            "The last guess is undone.",
+		layoutConfirm:     "It looks like another keyboard layout is on. Enter «%s» as «%s»? [Y/n]: ",
+		layoutConverted:   "It looks like another keyboard layout is on: «%s» is entered as «%s»",
+		yes:               []string{"y", "yes", "н", "д", "да"},
+		practiceInput:     "Enter a letter or the whole word (? for a hint, < to undo the last guess): ",
+		nothingToUndo:     "Nothing to undo: no guesses yet",
+		undoConfirm:       "The game is over. Undo the last guess? [y/N]: ",
+		replayForm:        "Replay saved: %s",
+		alreadyGuessed:    "Letter «%c» has already been guessed",
+		invalidLetter:     "«%c» is not a letter",
+		gameOver:          "The game is already over",
+		unknownCategory:   "There is no category «%s» in the dictionary",
+		unknownDifficulty: "There is no difficulty «%s»",
+		emptyWordList:     "The dictionary has no words of category «%s» and difficulty «%s»",
+		unknownTheme:      "There is no theme «%s»",
+		replayMismatch:    "The replay doesn't match the frames of theme «%s»",
+		errorForm:         "Error: %v",
+		saveConfirm:       "The game is interrupted. Save it to continue later? [Y/n]: ",
+		interrupted:       "The game is interrupted",
+		suspended:         "The game is saved, you can continue it next time",
+		resumeConfirm:     "There is an interrupted game: category «%s», difficulty «%s». Continue it? [Y/n]: ",
+		hintNumberForm:    "Hint %d of %d: %s",
+		sourceForm:        "Source: %s",
+		filterCategories:  "Categories separated by commas (skip for all):",
+		filterTags:        "Tags separated by commas (skip for any):",
+		invalidTag:        "No such tag. Please choose from the listed tags",
+		filterLength:      "Word length, e.g. 5-8, 5- or 6 (skip for any): ",
+		filterDistinct:    "Minimum number of distinct letters (skip for any): ",
+		filterUnseen:      "Exclude words you have already seen? [y/N]: ",
+		invalidValue:      "Invalid value, please try again",
+		noMatches:         "No words of difficulty «%s» match the chosen conditions",
+		wordGuessed:       "Word «%s» has already been guessed",
+		invalidWord:       "«%s» is not a word: it has no letters",
+		unknownWord:       "There is no word «%s» in the word list",
+		customCategory:    "player's word",
+	},
+}
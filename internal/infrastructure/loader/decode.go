@@ -0,0 +1,67 @@
+package loader
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Decode преобразует декодированные из файла данные общего вида (объекты, массивы, строки, числа) в target.
+func Decode(raw, target any) error {
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return fmt.Errorf("can`t marshal data: %w", err)
+	}
+
+	err = json.Unmarshal(data, target)
+	if err != nil {
+		return fmt.Errorf("can`t unmarshal data: %w", err)
+	}
+
+	return nil
+}
+
+// toGeneric переводит data в данные общего вида. Целые числа становятся int64, а дробные - float64,
+// чтобы форматы с отдельным целым типом, например TOML, не записывали целые числа дробными.
+func toGeneric(data any) (any, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("can`t marshal data: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+
+	var raw any
+
+	err = decoder.Decode(&raw)
+	if err != nil {
+		return nil, fmt.Errorf("can`t unmarshal data: %w", err)
+	}
+
+	return fromNumbers(raw), nil
+}
+
+// fromNumbers заменяет числа json.Number в данных общего вида на int64, если число целое, иначе на float64.
+func fromNumbers(raw any) any {
+	switch v := raw.(type) {
+	case map[string]any:
+		for key, value := range v {
+			v[key] = fromNumbers(value)
+		}
+	case []any:
+		for i, value := range v {
+			v[i] = fromNumbers(value)
+		}
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n
+		}
+
+		f, _ := v.Float64()
+
+		return f
+	}
+
+	return raw
+}
@@ -0,0 +1,73 @@
+package loader
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Format описывает формат файлов данных, переводящий содержимое файла в данные общего вида
+// (объекты map[string]any, массивы []any, строки, числа, логические значения) и обратно.
+type Format interface {
+	Unmarshal(data []byte) (any, error)
+	Marshal(v any) ([]byte, error)
+}
+
+// formats - словарь, сопоставляющий расширениям файлов их формат.
+var formats = map[string]Format{
+	".json":   jsonFormat{},
+	".yaml":   yamlFormat{},
+	".yml":    yamlFormat{},
+	".toml":   tomlFormat{},
+	".frames": framesTextFormat{},
+}
+
+// FormatOf возвращает формат файла, определённый по расширению его пути.
+func FormatOf(path string) (Format, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+
+	format, ok := formats[ext]
+	if !ok {
+		return nil, fmt.Errorf("unsupported file format %q, expected .json, .yaml, .yml, .toml or .frames", ext)
+	}
+
+	return format, nil
+}
+
+// FindFile возвращает путь к первому существующему файлу каталога dir с именем base и одним из расширений exts.
+// Если такого файла нет, возвращает false.
+func FindFile(dir, base string, exts ...string) (string, bool) {
+	for _, ext := range exts {
+		path := filepath.Join(dir, base+ext)
+
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, true
+		}
+	}
+
+	return "", false
+}
+
+// jsonFormat реализует Format для JSON.
+type jsonFormat struct{}
+
+// Unmarshal декодирует JSON.
+func (jsonFormat) Unmarshal(data []byte) (any, error) {
+	var v any
+
+	err := json.Unmarshal(data, &v)
+
+	return v, err
+}
+
+// Marshal кодирует JSON с отступами.
+func (jsonFormat) Marshal(v any) ([]byte, error) {
+	data, err := json.MarshalIndent(v, "", "    ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(data, '\n'), nil
+}
@@ -0,0 +1,155 @@
+package loader_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/loader"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatRoundTrip(t *testing.T) {
+	data := map[string]any{
+		"process": []any{
+			"             \n             \n|            \n=============",
+			"  O  \n /|\\ \n",
+			[]any{"line", ""},
+		},
+		"difficulties": map[string]any{"лёгкая": 7},
+		"hint":         `"Я не Тайлер Дерден!" (С)`,
+		"empty":        []any{},
+	}
+
+	for _, path := range []string{"frames.json", "frames.yaml", "frames.yml", "frames.toml"} {
+		format, err := loader.FormatOf(path)
+		assert.NoError(t, err)
+
+		encoded, err := format.Marshal(data)
+		assert.NoError(t, err, path)
+
+		decoded, err := format.Unmarshal(encoded)
+		assert.NoError(t, err, path)
+
+		var expected, actual any
+
+		assert.NoError(t, loader.Decode(data, &expected))
+		assert.NoError(t, loader.Decode(decoded, &actual))
+		assert.Equal(t, expected, actual, path)
+	}
+
+	_, err := loader.FormatOf("frames.txt")
+	assert.Error(t, err)
+}
+
+func TestTOMLUnmarshal(t *testing.T) {
+	format, err := loader.FormatOf("config.toml")
+	assert.NoError(t, err)
+
+	doc := `
+msFrameDelay = 1_250
+ratio = inf
+date = 2024-10-01T12:00:00Z
+
+[["персонажи"."лёгкая"]]
+word = 'Фродо'
+
+[["персонажи"."лёгкая"]]
+word = "Матроскин"
+hint = """
+Некий кот"""
+`
+
+	decoded, err := format.Unmarshal([]byte(doc))
+	assert.NoError(t, err)
+
+	root, ok := decoded.(map[string]any)
+	assert.True(t, ok)
+	assert.Equal(t, int64(1250), root["msFrameDelay"])
+	assert.True(t, math.IsInf(root["ratio"].(float64), 1))
+	assert.Equal(t, time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC), root["date"].(time.Time).UTC())
+	assert.Equal(t, map[string]any{
+		"лёгкая": []any{
+			map[string]any{"word": "Фродо"},
+			map[string]any{"word": "Матроскин", "hint": "Некий кот"},
+		},
+	}, root["персонажи"])
+
+	for _, doc := range []string{
+		"a = 1\na = 2",
+		"[table]\na = 1\n[table]\nb = 2",
+		"a = \"unterminated",
+		"a = [1, 2",
+		"[table\na = 1",
+	} {
+		_, err = format.Unmarshal([]byte(doc))
+		assert.Error(t, err, doc)
+	}
+}
+
+func TestLoadWordListFromFile(t *testing.T) {
+	content := "# список слов\nкот\n\n  Шар-пей  \r\n#кит\nкулак\n"
+	expected := []string{"кот", "Шар-пей", "кулак"}
+
+	var compressed bytes.Buffer
+
+	gz := gzip.NewWriter(&compressed)
+	_, err := gz.Write([]byte(content))
+	assert.NoError(t, err)
+	assert.NoError(t, gz.Close())
+
+	dir := t.TempDir()
+	files := map[string][]byte{
+		"words.txt":    []byte(content),
+		"words.txt.GZ": compressed.Bytes(),
+	}
+
+	for name, data := range files {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.WriteFile(path, data, 0o600))
+
+		list, err := loader.LoadWordListFromFile(path)
+		assert.NoError(t, err, name)
+		assert.Equal(t, expected, list, name)
+	}
+
+	_, err = loader.LoadWordListFromFile(filepath.Join(dir, "missing.txt"))
+	assert.Error(t, err)
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "broken.gz"), []byte(content), 0o600))
+
+	_, err = loader.LoadWordListFromFile(filepath.Join(dir, "broken.gz"))
+	assert.Error(t, err)
+}
+
+func TestSaveDataToFileReplacesFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "words.json")
+
+	assert.NoError(t, os.WriteFile(path, []byte("{}"), 0o644))
+	assert.NoError(t, loader.SaveDataToFile(path, map[string]int{"кот": 1}))
+
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o644), info.Mode().Perm())
+
+	var data map[string]int
+
+	assert.NoError(t, loader.LoadDataFromFile(path, &data))
+	assert.Equal(t, map[string]int{"кот": 1}, data)
+
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	created := filepath.Join(dir, "new", "stats.toml")
+	assert.NoError(t, loader.SaveDataToFile(created, map[string]int{"played": 2}))
+
+	info, err = os.Stat(created)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
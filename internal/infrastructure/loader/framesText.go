@@ -0,0 +1,286 @@
+package loader
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/frames"
+)
+
+// frameSeparator - строка, разделяющая кадры одного этапа в текстовом формате кадров: "--".
+// Разделитель может задавать задержку показа следующего кадра в миллисекундах и его текстовое описание,
+// например "-- 300", "-- : голова нарисована" или "-- 300 : голова нарисована".
+// Сразу после заголовка этапа разделитель задаёт задержку и описание первого кадра.
+var frameSeparator = regexp.MustCompile(`^--(?: (\d+))?(?: : (.+))?$`)
+
+// stageHeader - строка, начинающая этап в текстовом формате кадров, например "== process ==",
+// или под-анимацию кадра этапа, например "== process 5 ==".
+var stageHeader = regexp.MustCompile(`^== (\S+(?: \d+)?) ==$`)
+
+// stageOrder - порядок вывода известных этапов. Под-анимации выводятся после этапов по возрастанию номера кадра,
+// остальные этапы - в алфавитном порядке.
+var stageOrder = []string{"process", "victory", "defeat"}
+
+// framesTextFormat реализует Format для текстового формата кадров, в котором кадры записаны так,
+// как они выглядят на экране: этапы начинаются строками "== этап ==", кадры разделяются строками "--",
+// которые могут задавать задержку и описание кадра. Строки кадров сохраняются как есть, включая пустые строки
+// и пробелы в конце строк.
+type framesTextFormat struct{}
+
+// Unmarshal разбирает текстовый формат кадров в объект, сопоставляющий этапам и под-анимациям массивы кадров из строк.
+// Ошибки содержат номер строки, на которой они обнаружены.
+func (framesTextFormat) Unmarshal(data []byte) (any, error) {
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+
+	var (
+		stages = make(map[string]any)
+		stage  string
+		frame  []any
+		delay  int
+		desc   string
+	)
+
+	finishFrame := func(line int) error {
+		if stage == "" {
+			return nil
+		}
+
+		if len(frame) == 0 {
+			return fmt.Errorf("line %d: empty frame in stage %q", line, stage)
+		}
+
+		stages[stage] = append(stages[stage].([]any), frameValue(frame, delay, desc))
+
+		frame = nil
+		delay = 0
+		desc = ""
+
+		return nil
+	}
+
+	for i, line := range lines {
+		lineNumber := i + 1
+
+		switch match := stageHeader.FindStringSubmatch(line); {
+		case match != nil:
+			err := finishFrame(lineNumber)
+			if err != nil {
+				return nil, err
+			}
+
+			if _, ok := stages[match[1]]; ok {
+				return nil, fmt.Errorf("line %d: stage %q is defined twice", lineNumber, match[1])
+			}
+
+			stage = match[1]
+			stages[stage] = []any{}
+		case stage == "":
+			if strings.TrimSpace(line) != "" {
+				return nil, fmt.Errorf("line %d: expected stage header like \"== process ==\", got %q", lineNumber, line)
+			}
+		case frameSeparator.MatchString(line):
+			if len(frame) != 0 || len(stages[stage].([]any)) != 0 {
+				err := finishFrame(lineNumber)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			match := frameSeparator.FindStringSubmatch(line)
+			delay, _ = strconv.Atoi(match[1])
+			desc = match[2]
+		default:
+			frame = append(frame, line)
+		}
+	}
+
+	if stage != "" && len(frame) == 0 {
+		return nil, fmt.Errorf("line %d: stage %q ends with an empty frame", len(lines), stage)
+	}
+
+	err := finishFrame(len(lines))
+	if err != nil {
+		return nil, err
+	}
+
+	return stages, nil
+}
+
+// Marshal кодирует объект, сопоставляющий этапам массивы кадров, в текстовый формат кадров.
+// Кадр может быть массивом строк или многострочной строкой.
+func (framesTextFormat) Marshal(v any) ([]byte, error) {
+	stages, ok := v.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("frames must be an object of stages, got %T", v)
+	}
+
+	var sb strings.Builder
+
+	for _, stage := range orderedStages(stages) {
+		frs, ok := stages[stage].([]any)
+		if !ok {
+			return nil, fmt.Errorf("stage %q must be an array of frames, got %T", stage, stages[stage])
+		}
+
+		fmt.Fprintf(&sb, "== %s ==\n", stage)
+
+		for i, fr := range frs {
+			lines, delay, desc, err := frameLines(fr)
+			if err != nil {
+				return nil, fmt.Errorf("stage %q, frame %d: %w", stage, i, err)
+			}
+
+			if strings.Contains(desc, "\n") {
+				return nil, fmt.Errorf("stage %q, frame %d: description must be a single line", stage, i)
+			}
+
+			if i != 0 || delay != 0 || desc != "" {
+				sb.WriteString(separatorLine(delay, desc) + "\n")
+			}
+
+			for _, line := range lines {
+				if stageHeader.MatchString(line) || frameSeparator.MatchString(line) {
+					return nil, fmt.Errorf("stage %q, frame %d: line %q is reserved as a marker", stage, i, line)
+				}
+
+				sb.WriteString(line + "\n")
+			}
+		}
+	}
+
+	return []byte(sb.String()), nil
+}
+
+// frameValue возвращает кадр в виде массива строк или, если заданы задержка или описание,
+// в виде объекта с полями lines, msDelay и description.
+func frameValue(lines []any, delay int, desc string) any {
+	if delay == 0 && desc == "" {
+		return lines
+	}
+
+	obj := map[string]any{"lines": lines}
+
+	if delay != 0 {
+		obj["msDelay"] = delay
+	}
+
+	if desc != "" {
+		obj["description"] = desc
+	}
+
+	return obj
+}
+
+// separatorLine возвращает разделитель кадров с задержкой и описанием следующего кадра.
+func separatorLine(delay int, desc string) string {
+	line := "--"
+
+	if delay != 0 {
+		line += " " + strconv.Itoa(delay)
+	}
+
+	if desc != "" {
+		line += " : " + desc
+	}
+
+	return line
+}
+
+// frameLines возвращает строки, задержку и описание кадра, записанного массивом строк, многострочной строкой
+// или объектом со строками в поле lines, задержкой в поле msDelay и описанием в поле description.
+func frameLines(fr any) (lines []string, delay int, desc string, err error) {
+	if obj, ok := fr.(map[string]any); ok {
+		if number, ok := obj["msDelay"]; ok {
+			n, ok := number.(float64)
+			if !ok || n < 0 || n != float64(int(n)) {
+				return nil, 0, "", fmt.Errorf("frame delay must be a non-negative integer, got %v", number)
+			}
+
+			delay = int(n)
+		}
+
+		if text, ok := obj["description"]; ok {
+			desc, ok = text.(string)
+			if !ok {
+				return nil, 0, "", fmt.Errorf("frame description must be a string, got %T", text)
+			}
+		}
+
+		lines, _, _, err = frameLines(obj["lines"])
+
+		return lines, delay, desc, err
+	}
+
+	if text, ok := fr.(string); ok {
+		return strings.Split(strings.TrimSuffix(text, "\n"), "\n"), 0, "", nil
+	}
+
+	items, ok := fr.([]any)
+	if !ok || len(items) == 0 {
+		return nil, 0, "", fmt.Errorf("frame must be a non-empty array of strings or a multi-line string")
+	}
+
+	lines = make([]string, 0, len(items))
+
+	for _, item := range items {
+		line, ok := item.(string)
+		if !ok {
+			return nil, 0, "", fmt.Errorf("frame line must be a string, got %T", item)
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines, 0, "", nil
+}
+
+// orderedStages возвращает этапы в порядке вывода.
+func orderedStages(stages map[string]any) []string {
+	ordered := make([]string, 0, len(stages))
+	known := make(map[string]struct{}, len(stageOrder))
+
+	for _, stage := range stageOrder {
+		known[stage] = struct{}{}
+
+		if _, ok := stages[stage]; ok {
+			ordered = append(ordered, stage)
+		}
+	}
+
+	var rest []string
+
+	for stage := range stages {
+		if _, ok := known[stage]; !ok {
+			rest = append(rest, stage)
+		}
+	}
+
+	sort.Slice(rest, func(i, j int) bool {
+		return lessStage(rest[i], rest[j])
+	})
+
+	return append(ordered, rest...)
+}
+
+// lessStage сравнивает ключи под-анимаций "этап номер" по порядку этапов и номеру кадра,
+// а остальные ключи - в алфавитном порядке после под-анимаций.
+func lessStage(a, b string) bool {
+	stageA, indexA, okA := frames.ParseAnimationKey(a)
+	stageB, indexB, okB := frames.ParseAnimationKey(b)
+
+	switch {
+	case okA && okB && stageA != stageB:
+		return slices.Index(stageOrder, stageA) < slices.Index(stageOrder, stageB)
+	case okA && okB:
+		return indexA < indexB
+	case okA != okB:
+		return okA
+	default:
+		return a < b
+	}
+}
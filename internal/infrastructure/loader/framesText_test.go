@@ -0,0 +1,117 @@
+package loader_test
+
+import (
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/loader"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFramesTextUnmarshal(t *testing.T) {
+	format, err := loader.FormatOf("gallows.frames")
+	assert.NoError(t, err)
+
+	doc := "\n== process ==\n  |\n\n  |\n--\n /|\\\n== defeat ==\n  x  \n"
+
+	expected := map[string]any{
+		"process": []any{
+			[]any{"  |", "", "  |"},
+			[]any{" /|\\"},
+		},
+		"defeat": []any{
+			[]any{"  x  "},
+		},
+	}
+
+	actual, err := format.Unmarshal([]byte(doc))
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+
+	encoded, err := format.Marshal(actual)
+	assert.NoError(t, err)
+	assert.Equal(t, "== process ==\n  |\n\n  |\n--\n /|\\\n== defeat ==\n  x  \n", string(encoded))
+}
+
+func TestFramesTextUnmarshalErrors(t *testing.T) {
+	tt := []struct {
+		doc string
+		err string
+	}{
+		{
+			doc: "  |\n== process ==\n  |\n",
+			err: "line 1: expected stage header",
+		},
+		{
+			doc: "== process ==\n  |\n--\n--\n  |\n",
+			err: "line 4: empty frame in stage \"process\"",
+		},
+		{
+			doc: "== process ==\n  |\n== process ==\n  |\n",
+			err: "line 3: stage \"process\" is defined twice",
+		},
+		{
+			doc: "== process ==\n  |\n--\n",
+			err: "line 3: stage \"process\" ends with an empty frame",
+		},
+	}
+
+	format, err := loader.FormatOf("gallows.frames")
+	assert.NoError(t, err)
+
+	for _, tc := range tt {
+		_, err := format.Unmarshal([]byte(tc.doc))
+		assert.ErrorContains(t, err, tc.err)
+	}
+}
+
+func TestFramesTextAnimations(t *testing.T) {
+	format, err := loader.FormatOf("gallows.frames")
+	assert.NoError(t, err)
+
+	frames := map[string]any{
+		"process 10": []any{[]any{" O"}},
+		"defeat":     []any{[]any{"x"}},
+		"defeat 0":   []any{[]any{"X"}},
+		"process 2":  []any{[]any{" o"}},
+		"process":    []any{[]any{" |"}},
+	}
+
+	encoded, err := format.Marshal(frames)
+	assert.NoError(t, err)
+	assert.Equal(t,
+		"== process ==\n |\n== defeat ==\nx\n== process 2 ==\n o\n== process 10 ==\n O\n== defeat 0 ==\nX\n",
+		string(encoded))
+
+	decoded, err := format.Unmarshal(encoded)
+	assert.NoError(t, err)
+	assert.Equal(t, frames, decoded)
+}
+
+func TestFramesTextDelays(t *testing.T) {
+	format, err := loader.FormatOf("gallows.frames")
+	assert.NoError(t, err)
+
+	doc := "== victory ==\n-- 300\n o\n--\n O\n-- 1500 : человечек радуется\n\\O/\n-- : человечек ушёл\n\n"
+
+	expected := map[string]any{
+		"victory": []any{
+			map[string]any{"lines": []any{" o"}, "msDelay": 300},
+			[]any{" O"},
+			map[string]any{"lines": []any{"\\O/"}, "msDelay": 1500, "description": "человечек радуется"},
+			map[string]any{"lines": []any{""}, "description": "человечек ушёл"},
+		},
+	}
+
+	actual, err := format.Unmarshal([]byte(doc))
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+
+	var raw any
+
+	err = loader.Decode(actual, &raw)
+	assert.NoError(t, err)
+
+	encoded, err := format.Marshal(raw)
+	assert.NoError(t, err)
+	assert.Equal(t, doc, string(encoded))
+}
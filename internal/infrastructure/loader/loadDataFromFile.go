@@ -1,22 +1,32 @@
 package loader
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 )
 
-// LoadDataFromFile считывает файл по указанному path и десереализует данные в target.
+// LoadDataFromFile считывает файл по указанному path, десереализует данные в формате,
+// определённом по расширению файла, и записывает их в target.
 func LoadDataFromFile(path string, target any) error {
+	format, err := FormatOf(path)
+	if err != nil {
+		return fmt.Errorf("can`t detect format: %w", err)
+	}
+
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return fmt.Errorf("can`t read file: %w", err)
 	}
 
-	err = json.Unmarshal(data, target)
+	raw, err := format.Unmarshal(data)
 	if err != nil {
 		return fmt.Errorf("can`t unmarshal data: %w", err)
 	}
 
-	return nil
+	if generic, ok := target.(*any); ok {
+		*generic = raw
+		return nil
+	}
+
+	return Decode(raw, target)
 }
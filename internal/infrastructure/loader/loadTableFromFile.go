@@ -0,0 +1,75 @@
+package loader
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// tableReaders - словарь, сопоставляющий расширениям табличных файлов функцию чтения их строк.
+var tableReaders = map[string]func(r io.Reader) ([][]string, error){
+	".csv": readCSV,
+	".tsv": readTSV,
+}
+
+// LoadTableFromFile считывает строки табличного файла по указанному path в формате CSV или TSV,
+// определённом по расширению файла. Строки могут содержать разное количество полей,
+// пробелы по краям полей отбрасываются, пустые строки и строки, начинающиеся с #, пропускаются.
+func LoadTableFromFile(path string) ([][]string, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+
+	read, ok := tableReaders[ext]
+	if !ok {
+		return nil, fmt.Errorf("unsupported table format %q, expected .csv or .tsv", ext)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("can`t open file: %w", err)
+	}
+	defer file.Close()
+
+	rows, err := read(file)
+	if err != nil {
+		return nil, fmt.Errorf("can`t read table: %w", err)
+	}
+
+	for _, row := range rows {
+		for i := range row {
+			row[i] = strings.TrimSpace(row[i])
+		}
+	}
+
+	return rows, nil
+}
+
+// readCSV считывает строки CSV, поля которых могут быть заключены в кавычки.
+func readCSV(r io.Reader) ([][]string, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	return reader.ReadAll()
+}
+
+// readTSV считывает строки TSV, поля которых разделены табуляцией и не заключаются в кавычки.
+func readTSV(r io.Reader) ([][]string, error) {
+	var rows [][]string
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		rows = append(rows, strings.Split(line, "\t"))
+	}
+
+	return rows, scanner.Err()
+}
@@ -0,0 +1,53 @@
+package loader
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LoadWordListFromFile считывает список слов из текстового файла по указанному path, по одному слову в строке.
+// Файл с расширением .gz распаковывается при чтении. Пробелы по краям строк отбрасываются,
+// пустые строки и строки, начинающиеся с #, пропускаются.
+func LoadWordListFromFile(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("can`t open file: %w", err)
+	}
+	defer file.Close()
+
+	var r io.Reader = file
+
+	if strings.EqualFold(filepath.Ext(path), ".gz") {
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			return nil, fmt.Errorf("can`t open gzip stream: %w", err)
+		}
+		defer gz.Close()
+
+		r = gz
+	}
+
+	var list []string
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		list = append(list, line)
+	}
+
+	err = scanner.Err()
+	if err != nil {
+		return nil, fmt.Errorf("can`t read word list: %w", err)
+	}
+
+	return list, nil
+}
@@ -0,0 +1,100 @@
+package loader
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// SaveDataToFile сериализует data в формате, определённом по расширению файла, и записывает результат
+// в файл по указанному path, создавая недостающие каталоги. В JSON data кодируется напрямую,
+// чтобы поля структур сохранили порядок объявления, а ключи словарей были упорядочены;
+// для остальных форматов data предварительно переводится в данные общего вида с целыми числами.
+// Файл заменяется атомарно, поэтому сбой во время записи не повреждает прежнее содержимое.
+func SaveDataToFile(path string, data any) error {
+	format, err := FormatOf(path)
+	if err != nil {
+		return fmt.Errorf("can`t detect format: %w", err)
+	}
+
+	raw := data
+
+	if _, ok := format.(jsonFormat); !ok {
+		raw, err = toGeneric(data)
+		if err != nil {
+			return fmt.Errorf("can`t convert data: %w", err)
+		}
+	}
+
+	encoded, err := format.Marshal(raw)
+	if err != nil {
+		return fmt.Errorf("can`t marshal data: %w", err)
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return fmt.Errorf("can`t create directory: %w", err)
+	}
+
+	err = writeFileAtomic(path, encoded)
+	if err != nil {
+		return fmt.Errorf("can`t write file: %w", err)
+	}
+
+	return nil
+}
+
+// writeFileAtomic записывает data во временный файл в каталоге path, сбрасывает его на диск и переименовывает в path.
+// Права существующего файла сохраняются, новый файл создаётся с правами 0600.
+func writeFileAtomic(path string, data []byte) (err error) {
+	mode := fs.FileMode(0o600)
+
+	info, err := os.Stat(path)
+	switch {
+	case err == nil:
+		mode = info.Mode().Perm()
+	case !errors.Is(err, fs.ErrNotExist):
+		return fmt.Errorf("can`t stat file: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("can`t create temporary file: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	_, err = tmp.Write(data)
+	if err != nil {
+		return fmt.Errorf("can`t write temporary file: %w", err)
+	}
+
+	err = tmp.Chmod(mode)
+	if err != nil {
+		return fmt.Errorf("can`t set file mode: %w", err)
+	}
+
+	err = tmp.Sync()
+	if err != nil {
+		return fmt.Errorf("can`t sync temporary file: %w", err)
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		return fmt.Errorf("can`t close temporary file: %w", err)
+	}
+
+	err = os.Rename(tmp.Name(), path)
+	if err != nil {
+		return fmt.Errorf("can`t replace file: %w", err)
+	}
+
+	return nil
+}
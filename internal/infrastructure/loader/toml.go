@@ -0,0 +1,70 @@
+package loader
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/BurntSushi/toml"
+)
+
+// tomlFormat реализует Format для TOML.
+type tomlFormat struct{}
+
+// Unmarshal декодирует TOML, приводя массивы таблиц к []any. Даты и время декодируются в time.Time.
+func (tomlFormat) Unmarshal(data []byte) (any, error) {
+	var v map[string]any
+
+	err := toml.Unmarshal(data, &v)
+	if err != nil {
+		return nil, err
+	}
+
+	return normalizeTOML(v), nil
+}
+
+// Marshal кодирует TOML. Корнем документа TOML может быть только объект.
+func (tomlFormat) Marshal(v any) ([]byte, error) {
+	root, ok := v.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("toml document root must be an object, got %T", v)
+	}
+
+	var buf bytes.Buffer
+
+	encoder := toml.NewEncoder(&buf)
+	encoder.Indent = ""
+
+	err := encoder.Encode(root)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// normalizeTOML рекурсивно заменяет массивы таблиц []map[string]any на []any.
+func normalizeTOML(v any) any {
+	switch value := v.(type) {
+	case map[string]any:
+		for key, item := range value {
+			value[key] = normalizeTOML(item)
+		}
+
+		return value
+	case []map[string]any:
+		normalized := make([]any, len(value))
+		for i, item := range value {
+			normalized[i] = normalizeTOML(item)
+		}
+
+		return normalized
+	case []any:
+		for i, item := range value {
+			value[i] = normalizeTOML(item)
+		}
+
+		return value
+	default:
+		return v
+	}
+}
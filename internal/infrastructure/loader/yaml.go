@@ -0,0 +1,74 @@
+package loader
+
+import (
+	"bytes"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// yamlIndent - шаг отступа вложенных узлов YAML.
+const yamlIndent = 2
+
+// yamlFormat реализует Format для YAML.
+type yamlFormat struct{}
+
+// Unmarshal декодирует YAML, приводя ключи всех объектов к строкам.
+func (yamlFormat) Unmarshal(data []byte) (any, error) {
+	var v any
+
+	err := yaml.Unmarshal(data, &v)
+	if err != nil {
+		return nil, err
+	}
+
+	return normalizeYAML(v), nil
+}
+
+// Marshal кодирует YAML с помощью yaml.v3. Ключи объектов выводятся в алфавитном порядке,
+// многострочные строки - блочными скалярами, если это возможно без потери пробелов.
+func (yamlFormat) Marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(yamlIndent)
+
+	err := encoder.Encode(v)
+	if err != nil {
+		return nil, err
+	}
+
+	err = encoder.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// normalizeYAML рекурсивно заменяет объекты с нестроковыми ключами на map[string]any.
+func normalizeYAML(v any) any {
+	switch value := v.(type) {
+	case map[string]any:
+		for key, item := range value {
+			value[key] = normalizeYAML(item)
+		}
+
+		return value
+	case map[any]any:
+		normalized := make(map[string]any, len(value))
+		for key, item := range value {
+			normalized[fmt.Sprint(key)] = normalizeYAML(item)
+		}
+
+		return normalized
+	case []any:
+		for i, item := range value {
+			value[i] = normalizeYAML(item)
+		}
+
+		return value
+	default:
+		return v
+	}
+}
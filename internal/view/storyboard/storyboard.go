@@ -1,46 +1,104 @@
 package storyboard
 
 import (
-	"math"
+	"fmt"
 
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/frames"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/random"
 )
 
 // CreateStoryboard создаёт раскадровку типа StageFramesMap по входному набору кадров и количеству попыток.
-func CreateStoryboard(sfp frames.StageFramesMap, attempts int) frames.StageFramesMap {
-	storyboard := frames.New(len(sfp["victory"]))
-	copy(storyboard["defeat"], sfp["defeat"])   // кадры анимации поражения соответствуют предусмотренному набору кадров
-	copy(storyboard["victory"], sfp["victory"]) // кадры анимации победы соответствуют предусмотренному набору кадров
+func CreateStoryboard(rnd random.Source, sfp frames.StageFramesMap, attempts int) (frames.StageFramesMap, error) {
+	frameIndexes, err := GenerateFrameIndexes(rnd, len(sfp["process"]), attempts)
+	if err != nil {
+		return nil, err
+	}
+
+	return Build(sfp, frameIndexes), nil
+}
+
+// Build создаёт раскадровку типа StageFramesMap по входному набору кадров и номерам включаемых в неё кадров процесса.
+// Все кадры раскадровки дополняются до размера наибольшего кадра набора, чтобы изображение не смещалось между кадрами.
+// Под-анимации кадров победы и поражения встраиваются в анимации этапов, а переход к каждому следующему кадру процесса
+// сохраняется под-анимацией этого кадра: в неё входят пропущенные кадры набора и их собственные под-анимации.
+func Build(sfp frames.StageFramesMap, frameIndexes []int) frames.StageFramesMap {
+	width, height := sfp.Size()
+
+	storyboard := make(frames.StageFramesMap)
+
+	for _, stage := range []string{"victory", "defeat"} {
+		for i, fr := range sfp[stage] {
+			storyboard[stage] = appendPadded(storyboard[stage], sfp.Animation(stage, i), width, height)
+			storyboard[stage] = append(storyboard[stage], fr.Pad(width, height))
+		}
+	}
+
+	for i, frameIndex := range frameIndexes {
+		storyboard["process"] = append(storyboard["process"], sfp["process"][frameIndex].Pad(width, height))
+
+		if i == 0 {
+			continue
+		}
+
+		var transition []frames.Frame
 
-	frameIndexes := generateFrameIndexes(sfp, attempts)
-	for _, frameIndex := range frameIndexes {
-		frame := make(frames.Frame, len(sfp["process"][frameIndex]))
-		copy(frame, sfp["process"][frameIndex])
+		for j := frameIndexes[i-1] + 1; j <= frameIndex; j++ {
+			transition = appendPadded(transition, sfp.Animation("process", j), width, height)
 
-		storyboard["process"] = append(storyboard["process"], frame)
+			if j != frameIndex {
+				transition = append(transition, sfp["process"][j].Pad(width, height))
+			}
+		}
+
+		if len(transition) != 0 {
+			storyboard[frames.AnimationKey("process", i)] = transition
+		}
 	}
 
 	return storyboard
 }
 
-// generateFrameIndexes генерирует номера кадров из исходного набора, которые будут включены в раскадровку.
-func generateFrameIndexes(frs frames.StageFramesMap, attempts int) []int {
-	// Выберем кадры для каждой новой попытки
-	framesNumber := len(frs["process"]) // количество кадров в изначальном наборе
-	segmentsNumber := attempts          // количество кадров, необходимое для соответствия каждой новой попытке
-	segmentLength := int(math.Ceil(float64(framesNumber) / float64(segmentsNumber)))
+// appendPadded добавляет к dst дополненные до заданного размера копии кадров src.
+func appendPadded(dst, src []frames.Frame, width, height int) []frames.Frame {
+	for _, fr := range src {
+		dst = append(dst, fr.Pad(width, height))
+	}
+
+	return dst
+}
+
+// GenerateFrameIndexes генерирует номера кадров из исходного набора, которые будут включены в раскадровку.
+// Номера не убывают, первый и последний кадры набора включаются всегда.
+func GenerateFrameIndexes(rnd random.Source, framesNumber, attempts int) ([]int, error) {
+	if framesNumber < 1 || attempts < 1 {
+		return nil, nil
+	}
+
+	frameIndexes := make([]int, attempts) // номера кадров, которые нужно включить в раскадровку; нулевой кадр включается всегда
+
+	if framesNumber < attempts {
+		// Кадров меньше, чем попыток: равномерно повторяем кадры набора
+		for i := 1; i < attempts; i++ {
+			frameIndexes[i] = i * (framesNumber - 1) / (attempts - 1)
+		}
+
+		return frameIndexes, nil
+	}
 
-	frameIndexes := make([]int, segmentsNumber) // номера кадров, которые нужно включить в раскадровку
-	frameIndexes[0] = 0                         // нулевой кадр всегда включается в раскадровку
+	// Разбиваем набор на сегменты по количеству попыток и выбираем случайный кадр внутри каждого сегмента.
+	// Границы сегментов вычисляются округлением вниз, поэтому индекс не выходит за пределы набора.
+	for i := 1; i < attempts-1; i++ {
+		lo := i * framesNumber / attempts
+		hi := (i + 1) * framesNumber / attempts
+		offset, err := rnd.Int(hi - lo)
+		if err != nil {
+			return nil, fmt.Errorf("can`t choose frame: %w", err)
+		}
 
-	// генерируем в границах сегмента индекс кадра
-	for i := 1; i < segmentsNumber-1; i++ {
-		index := i*segmentLength + random.RandInt(segmentLength)
-		frameIndexes[i] = index
+		frameIndexes[i] = lo + offset
 	}
 
-	frameIndexes[segmentsNumber-1] = framesNumber - 1 // Последний кадр всегда включается в раскадровку
+	frameIndexes[attempts-1] = framesNumber - 1 // Последний кадр всегда включается в раскадровку
 
-	return frameIndexes
+	return frameIndexes, nil
 }
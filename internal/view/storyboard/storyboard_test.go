@@ -0,0 +1,87 @@
+package storyboard_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/frames"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/random"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/view/storyboard"
+	"github.com/stretchr/testify/assert"
+)
+
+// frame возвращает кадр из переданных строк.
+func frame(lines ...string) frames.Frame {
+	return frames.Frame{Lines: lines}
+}
+
+// newFrames возвращает набор кадров, процесс которого состоит из processFrames пронумерованных кадров разной ширины.
+func newFrames(processFrames int) frames.StageFramesMap {
+	sfm := frames.StageFramesMap{
+		"victory": {frame("\\o/"), frame(" o ")},
+		"defeat":  {frame("x"), frame("X", "|")},
+	}
+
+	for i := range processFrames {
+		sfm["process"] = append(sfm["process"], frame(fmt.Sprint(i)))
+	}
+
+	return sfm
+}
+
+func TestCreateStoryboard(t *testing.T) {
+	tt := []struct {
+		processFrames int
+		attempts      int
+	}{
+		{processFrames: 33, attempts: 7},
+		{processFrames: 10, attempts: 7},
+		{processFrames: 7, attempts: 7},
+		{processFrames: 3, attempts: 7},
+		{processFrames: 1, attempts: 5},
+		{processFrames: 5, attempts: 1},
+	}
+
+	for _, tc := range tt {
+		for seed := range uint64(20) {
+			sfm := newFrames(tc.processFrames)
+			sb, err := storyboard.CreateStoryboard(random.New(seed), sfm, tc.attempts)
+			assert.NoError(t, err)
+
+			process := sb["process"]
+			assert.Len(t, process, tc.attempts)
+			assert.Equal(t, sfm["process"][tc.processFrames-1].Pad(3, 2), process[tc.attempts-1])
+
+			if tc.attempts > 1 {
+				assert.Equal(t, sfm["process"][0].Pad(3, 2), process[0])
+			}
+
+			for _, stage := range frames.Stages {
+				for _, fr := range sb[stage] {
+					width, height := fr.Size()
+					assert.Equal(t, 3, width)
+					assert.Equal(t, 2, height)
+				}
+			}
+		}
+	}
+}
+
+func TestCreateStoryboardTransitions(t *testing.T) {
+	sfm := newFrames(5)
+	sfm[frames.AnimationKey("process", 4)] = []frames.Frame{frame("4a"), {Lines: []string{"4b"}, MsDelay: 300}}
+	sfm[frames.AnimationKey("defeat", 1)] = []frames.Frame{frame("x!")}
+
+	sb, err := storyboard.CreateStoryboard(random.New(0), sfm, 2)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []frames.Frame{frame("   ", "0  "), frame("   ", "4  ")}, sb["process"])
+	assert.Equal(t, []frames.Frame{
+		frame("   ", "1  "),
+		frame("   ", "2  "),
+		frame("   ", "3  "),
+		frame("   ", "4a "),
+		{Lines: []string{"   ", "4b "}, MsDelay: 300},
+	}, sb.Animation("process", 1))
+	assert.Equal(t, []frames.Frame{frame("   ", "x  "), frame("   ", "x! "), frame("X  ", "|  ")}, sb["defeat"])
+}
@@ -0,0 +1,51 @@
+package hangman
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrInvalidOptions - параметры игры недопустимы: слово пустое или попыток меньше одной.
+	ErrInvalidOptions = errors.New("invalid game options")
+	// ErrInvalidLetter - переданный символ не является буквой.
+	ErrInvalidLetter = errors.New("invalid letter")
+	// ErrInvalidWord - переданное слово не содержит букв.
+	ErrInvalidWord = errors.New("invalid word")
+	// ErrAlreadyGuessed - буква или слово уже были названы в этой игре.
+	ErrAlreadyGuessed = errors.New("already guessed")
+	// ErrGameOver - игра уже закончена победой или поражением.
+	ErrGameOver = errors.New("game is over")
+)
+
+// LetterError - ошибка попытки назвать букву. Err - одна из ошибок ErrInvalidLetter, ErrAlreadyGuessed, ErrGameOver.
+type LetterError struct {
+	Letter rune
+	Err    error
+}
+
+// Error возвращает описание ошибки.
+func (e *LetterError) Error() string {
+	return fmt.Sprintf("can`t guess letter %q: %v", e.Letter, e.Err)
+}
+
+// Unwrap возвращает причину ошибки.
+func (e *LetterError) Unwrap() error {
+	return e.Err
+}
+
+// WordError - ошибка попытки назвать слово целиком. Err - одна из ошибок ErrInvalidWord, ErrAlreadyGuessed, ErrGameOver.
+type WordError struct {
+	Word string
+	Err  error
+}
+
+// Error возвращает описание ошибки.
+func (e *WordError) Error() string {
+	return fmt.Sprintf("can`t guess word %q: %v", e.Word, e.Err)
+}
+
+// Unwrap возвращает причину ошибки.
+func (e *WordError) Unwrap() error {
+	return e.Err
+}
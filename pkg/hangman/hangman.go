@@ -0,0 +1,268 @@
+// Package hangman реализует движок игры "Виселица" без ввода-вывода: игра - конечный автомат,
+// который принимает буквы методом Guess и слова целиком методом GuessWord и сообщает своё состояние методом State.
+package hangman
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Hidden - символ, которым в отображаемом слове обозначаются неоткрытые буквы.
+const Hidden = '_'
+
+// Status - стадия игры.
+type Status int
+
+// Стадии игры.
+const (
+	// InProgress - игра продолжается.
+	InProgress Status = iota
+	// Won - слово отгадано.
+	Won
+	// Lost - попытки закончились, слово не отгадано.
+	Lost
+)
+
+// String возвращает название стадии игры.
+func (s Status) String() string {
+	switch s {
+	case InProgress:
+		return "in progress"
+	case Won:
+		return "won"
+	case Lost:
+		return "lost"
+	default:
+		return fmt.Sprintf("Status(%d)", int(s))
+	}
+}
+
+// Options хранит параметры новой игры: загаданное слово, подсказку к нему, дополнительные подсказки
+// в порядке раскрытия и количество попыток. Непустая подсказка Hint раскрывается первой.
+type Options struct {
+	Word     string
+	Hint     string
+	Hints    []string
+	Attempts int
+}
+
+// State хранит состояние игры: отображаемое слово, буквы в порядке их называния,
+// количество оставшихся и всех попыток, количество раскрытых и всех подсказок и стадию игры.
+type State struct {
+	DisplayedWord []rune
+	LettersUsed   []rune
+	AttemptsLeft  int
+	MaxAttempts   int
+	HintsShown    int
+	HintsTotal    int
+	Status        Status
+}
+
+// Result хранит результат попытки назвать букву или слово целиком: букву или слово, признак попадания, позиции,
+// на которых открыты буквы, количество оставшихся попыток и стадию игры после попытки.
+type Result struct {
+	Letter       rune
+	Word         string
+	Hit          bool
+	Positions    []int
+	AttemptsLeft int
+	Status       Status
+}
+
+// Game - игра "Виселица". Нулевое значение не готово к использованию, игра создаётся функцией NewGame.
+type Game struct {
+	word         []rune
+	hints        []string
+	hintsShown   int
+	displayed    []rune
+	hidden       int
+	positions    map[rune][]int
+	lettersUsed  []rune
+	wordsUsed    []string
+	attemptsLeft int
+	maxAttempts  int
+}
+
+// NewGame возвращает новую игру с переданными параметрами. Слово приводится к нижнему регистру,
+// символы слова, не являющиеся буквами, открыты с самого начала.
+func NewGame(opts Options) (*Game, error) {
+	word := []rune(strings.ToLower(opts.Word))
+
+	if len(word) == 0 {
+		return nil, fmt.Errorf("%w: word is empty", ErrInvalidOptions)
+	}
+
+	if opts.Attempts < 1 {
+		return nil, fmt.Errorf("%w: attempts must be at least 1, got %d", ErrInvalidOptions, opts.Attempts)
+	}
+
+	hints := slices.Clone(opts.Hints)
+	if opts.Hint != "" {
+		hints = append([]string{opts.Hint}, hints...)
+	}
+
+	g := &Game{
+		word:         word,
+		hints:        hints,
+		displayed:    make([]rune, len(word)),
+		positions:    make(map[rune][]int),
+		attemptsLeft: opts.Attempts,
+		maxAttempts:  opts.Attempts,
+	}
+
+	for i, r := range word {
+		if !unicode.IsLetter(r) {
+			g.displayed[i] = r
+			continue
+		}
+
+		g.displayed[i] = Hidden
+		g.hidden++
+		g.positions[r] = append(g.positions[r], i)
+	}
+
+	return g, nil
+}
+
+// Guess называет букву без учёта регистра. Попадание открывает букву на всех её позициях,
+// промах расходует попытку. Повторная буква, не буква и попытка после окончания игры возвращают *LetterError,
+// не изменяя состояние игры.
+func (g *Game) Guess(letter rune) (Result, error) {
+	letter = unicode.ToLower(letter)
+
+	switch {
+	case g.Status() != InProgress:
+		return Result{}, &LetterError{Letter: letter, Err: ErrGameOver}
+	case !unicode.IsLetter(letter):
+		return Result{}, &LetterError{Letter: letter, Err: ErrInvalidLetter}
+	case slices.Contains(g.lettersUsed, letter):
+		return Result{}, &LetterError{Letter: letter, Err: ErrAlreadyGuessed}
+	}
+
+	g.lettersUsed = append(g.lettersUsed, letter)
+
+	positions := g.positions[letter]
+	for _, p := range positions {
+		g.displayed[p] = letter
+		g.hidden--
+	}
+
+	if len(positions) == 0 {
+		g.attemptsLeft--
+	}
+
+	return Result{
+		Letter:       letter,
+		Hit:          len(positions) != 0,
+		Positions:    slices.Clone(positions),
+		AttemptsLeft: g.attemptsLeft,
+		Status:       g.Status(),
+	}, nil
+}
+
+// GuessWord называет слово целиком без учёта регистра. Верное слово открывает все оставшиеся буквы,
+// неверное расходует попытку. Повторное слово, слово без букв и попытка после окончания игры возвращают *WordError,
+// не изменяя состояние игры.
+func (g *Game) GuessWord(word string) (Result, error) {
+	word = strings.ToLower(word)
+
+	switch {
+	case g.Status() != InProgress:
+		return Result{}, &WordError{Word: word, Err: ErrGameOver}
+	case !strings.ContainsFunc(word, unicode.IsLetter):
+		return Result{}, &WordError{Word: word, Err: ErrInvalidWord}
+	case slices.Contains(g.wordsUsed, word):
+		return Result{}, &WordError{Word: word, Err: ErrAlreadyGuessed}
+	}
+
+	g.wordsUsed = append(g.wordsUsed, word)
+
+	var positions []int
+
+	if word == string(g.word) {
+		for i, r := range g.word {
+			if g.displayed[i] != r {
+				g.displayed[i] = r
+				positions = append(positions, i)
+			}
+		}
+
+		g.hidden = 0
+	} else {
+		g.attemptsLeft--
+	}
+
+	return Result{
+		Word:         word,
+		Hit:          word == string(g.word),
+		Positions:    positions,
+		AttemptsLeft: g.attemptsLeft,
+		Status:       g.Status(),
+	}, nil
+}
+
+// Hint возвращает первую подсказку к загаданному слову или пустую строку, если подсказок нет.
+// В отличие от NextHint не раскрывает подсказки.
+func (g *Game) Hint() string {
+	if len(g.hints) == 0 {
+		return ""
+	}
+
+	return g.hints[0]
+}
+
+// NextHint раскрывает следующую подсказку к загаданному слову и возвращает её вместе с её номером, начиная с 1.
+// Когда все подсказки раскрыты, повторно возвращается последняя. Если подсказок нет, возвращаются пустая строка и 0.
+func (g *Game) NextHint() (hint string, number int) {
+	if len(g.hints) == 0 {
+		return "", 0
+	}
+
+	if g.hintsShown < len(g.hints) {
+		g.hintsShown++
+	}
+
+	return g.hints[g.hintsShown-1], g.hintsShown
+}
+
+// Word возвращает загаданное слово в нижнем регистре.
+func (g *Game) Word() string {
+	return string(g.word)
+}
+
+// Status возвращает стадию игры.
+func (g *Game) Status() Status {
+	switch {
+	case g.hidden == 0:
+		return Won
+	case g.attemptsLeft == 0:
+		return Lost
+	default:
+		return InProgress
+	}
+}
+
+// State возвращает копию состояния игры, изменение которой не влияет на игру.
+func (g *Game) State() State {
+	return State{
+		DisplayedWord: slices.Clone(g.displayed),
+		LettersUsed:   slices.Clone(g.lettersUsed),
+		AttemptsLeft:  g.attemptsLeft,
+		MaxAttempts:   g.maxAttempts,
+		HintsShown:    g.hintsShown,
+		HintsTotal:    len(g.hints),
+		Status:        g.Status(),
+	}
+}
+
+// Clone возвращает независимую копию игры, например, чтобы сохранить ход для последующей отмены.
+func (g *Game) Clone() *Game {
+	clone := *g
+	clone.displayed = slices.Clone(g.displayed)
+	clone.lettersUsed = slices.Clone(g.lettersUsed)
+	clone.wordsUsed = slices.Clone(g.wordsUsed)
+
+	return &clone
+}
@@ -0,0 +1,108 @@
+package hangman_test
+
+import (
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/pkg/hangman"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGame(t *testing.T) {
+	g, err := hangman.NewGame(hangman.Options{Word: "Шар-пей", Hint: "порода собак", Hints: []string{"морщинистая"}, Attempts: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, "порода собак", g.Hint())
+	assert.Equal(t, []rune("___-___"), g.State().DisplayedWord)
+
+	for i, expected := range []string{"порода собак", "морщинистая", "морщинистая"} {
+		hint, number := g.NextHint()
+		assert.Equal(t, expected, hint)
+		assert.Equal(t, min(i+1, 2), number)
+	}
+
+	assert.Equal(t, 2, g.State().HintsShown)
+	assert.Equal(t, "порода собак", g.Hint())
+
+	result, err := g.Guess('Ш')
+	assert.NoError(t, err)
+	assert.Equal(t, hangman.Result{Letter: 'ш', Hit: true, Positions: []int{0}, AttemptsLeft: 2, Status: hangman.InProgress}, result)
+
+	result, err = g.Guess('о')
+	assert.NoError(t, err)
+	assert.False(t, result.Hit)
+	assert.Equal(t, 1, result.AttemptsLeft)
+
+	_, err = g.Guess('ш')
+	assert.ErrorIs(t, err, hangman.ErrAlreadyGuessed)
+
+	var letterErr *hangman.LetterError
+
+	_, err = g.Guess('1')
+	assert.ErrorAs(t, err, &letterErr)
+	assert.Equal(t, '1', letterErr.Letter)
+	assert.ErrorIs(t, err, hangman.ErrInvalidLetter)
+
+	saved := g.Clone()
+
+	for _, letter := range "арпей" {
+		_, err = g.Guess(letter)
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, hangman.State{
+		DisplayedWord: []rune("шар-пей"),
+		LettersUsed:   []rune("шоарпей"),
+		AttemptsLeft:  1,
+		MaxAttempts:   2,
+		HintsShown:    2,
+		HintsTotal:    2,
+		Status:        hangman.Won,
+	}, g.State())
+
+	_, err = g.Guess('я')
+	assert.ErrorIs(t, err, hangman.ErrGameOver)
+
+	result, err = saved.Guess('я')
+	assert.NoError(t, err)
+	assert.Equal(t, hangman.Lost, result.Status)
+	assert.Equal(t, []rune("ш__-___"), saved.State().DisplayedWord)
+
+	_, err = hangman.NewGame(hangman.Options{Word: "", Attempts: 1})
+	assert.ErrorIs(t, err, hangman.ErrInvalidOptions)
+}
+
+func TestGuessWord(t *testing.T) {
+	g, err := hangman.NewGame(hangman.Options{Word: "Шар-пей", Attempts: 3})
+	assert.NoError(t, err)
+
+	_, err = g.Guess('а')
+	assert.NoError(t, err)
+
+	result, err := g.GuessWord("Шарпей")
+	assert.NoError(t, err)
+	assert.Equal(t, hangman.Result{Word: "шарпей", AttemptsLeft: 2, Status: hangman.InProgress}, result)
+
+	_, err = g.GuessWord("шарпей")
+	assert.ErrorIs(t, err, hangman.ErrAlreadyGuessed)
+
+	var wordErr *hangman.WordError
+
+	_, err = g.GuessWord("-1-")
+	assert.ErrorAs(t, err, &wordErr)
+	assert.Equal(t, "-1-", wordErr.Word)
+	assert.ErrorIs(t, err, hangman.ErrInvalidWord)
+
+	saved := g.Clone()
+
+	result, err = g.GuessWord("ШАР-ПЕЙ")
+	assert.NoError(t, err)
+	assert.Equal(t, hangman.Result{Word: "шар-пей", Hit: true, Positions: []int{0, 2, 4, 5, 6}, AttemptsLeft: 2, Status: hangman.Won}, result)
+	assert.Equal(t, []rune("шар-пей"), g.State().DisplayedWord)
+
+	_, err = g.GuessWord("шар-пей")
+	assert.ErrorIs(t, err, hangman.ErrGameOver)
+
+	result, err = saved.GuessWord("мопс")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.AttemptsLeft)
+	assert.Equal(t, []rune("_а_-___"), saved.State().DisplayedWord)
+}